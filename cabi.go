@@ -0,0 +1,47 @@
+package main
+
+import (
+	"math/big"
+
+	"poseidonAlgorithm/poseidon"
+)
+
+// Коди повернення C ABI (capi/poseidon.h)
+const (
+	cabiOK        = 0  // POSEIDON_OK
+	cabiErrNull   = -1 // POSEIDON_ERR_NULL - нульовий вказівник
+	cabiErrLength = -2 // POSEIDON_ERR_LENGTH - кількість елементів не в межах 1..16
+	cabiErrField  = -3 // POSEIDON_ERR_FIELD - елемент не менший за q
+)
+
+// cabiHash - функція гешування n елементів поля, заданих 32-байтовими big-endian блоками в elems; геш записується в out
+func cabiHash(elems []byte, n int, out *[32]byte) int {
+	if n < 1 || n > poseidon.INPUTS || len(elems) != n*32 {
+		return cabiErrLength
+	}
+
+	input := make([]*big.Int, n)
+
+	for i := range input {
+		e, err := poseidon.ElementFromBytes(elems[i*32 : (i+1)*32])
+		if err != nil {
+			return cabiErrField
+		}
+		input[i] = e.BigInt()
+	}
+
+	poseidon.Hash(input).FillBytes(out[:])
+
+	return cabiOK
+}
+
+// cabiHashBytes - функція гешування масиву байтів з HashBytes (або HashBytesStrict); геш записується в out
+func cabiHashBytes(data []byte, strict bool, out *[32]byte) int {
+	if strict {
+		poseidon.HashBytesStrict(data).FillBytes(out[:])
+	} else {
+		poseidon.HashBytes(data).FillBytes(out[:])
+	}
+
+	return cabiOK
+}
@@ -0,0 +1,73 @@
+//go:build cgo
+
+package main
+
+// #include <stddef.h>
+// #include <stdint.h>
+import "C"
+
+import (
+	"math"
+	"unsafe"
+
+	"poseidonAlgorithm/poseidon"
+)
+
+// Функції C ABI для збірки з -buildmode=c-shared (libposeidon.so); оголошення - в capi/poseidon.h.
+// Вхідні масиви лише читаються і не зберігаються після повернення, тому виклики безпечні з кількох потоків.
+
+//export poseidon_hash
+func poseidon_hash(elems *C.uint8_t, n C.size_t, out *C.uint8_t) C.int {
+	if n < 1 || n > poseidon.INPUTS {
+		return cabiErrLength
+	}
+
+	if elems == nil || out == nil {
+		return cabiErrNull
+	}
+
+	var digest [32]byte
+	rc := cabiHash(unsafe.Slice((*byte)(unsafe.Pointer(elems)), int(n)*32), int(n), &digest)
+
+	return C.int(cabiCopy(rc, &digest, out))
+}
+
+//export poseidon_hash_bytes
+func poseidon_hash_bytes(data *C.uint8_t, length C.size_t, out *C.uint8_t) C.int {
+	return C.int(cabiHashBytesC(data, length, false, out))
+}
+
+//export poseidon_hash_bytes_strict
+func poseidon_hash_bytes_strict(data *C.uint8_t, length C.size_t, out *C.uint8_t) C.int {
+	return C.int(cabiHashBytesC(data, length, true, out))
+}
+
+// cabiHashBytesC - спільна частина poseidon_hash_bytes і poseidon_hash_bytes_strict (data може бути NULL, якщо length = 0)
+func cabiHashBytesC(data *C.uint8_t, length C.size_t, strict bool, out *C.uint8_t) int {
+	if out == nil || (data == nil && length > 0) {
+		return cabiErrNull
+	}
+
+	if uint64(length) > math.MaxInt32 {
+		return cabiErrLength
+	}
+
+	var msg []byte
+	if length > 0 {
+		msg = unsafe.Slice((*byte)(unsafe.Pointer(data)), int(length))
+	}
+
+	var digest [32]byte
+	rc := cabiHashBytes(msg, strict, &digest)
+
+	return cabiCopy(rc, &digest, out)
+}
+
+// cabiCopy - функція копіювання гешу в буфер out викликаючої сторони, якщо гешування успішне
+func cabiCopy(rc int, digest *[32]byte, out *C.uint8_t) int {
+	if rc == cabiOK {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(out)), 32), digest[:])
+	}
+
+	return rc
+}
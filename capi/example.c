@@ -0,0 +1,39 @@
+/*
+ * example.c - calls libposeidon.so through poseidon.h; used by TestCABI (capi_test.go).
+ *
+ *   go build -buildmode=c-shared -o libposeidon.so .
+ *   cc -I capi capi/example.c -L . -lposeidon -Wl,-rpath,. -o example && ./example
+ */
+#include <stdio.h>
+#include <string.h>
+
+#include "poseidon.h"
+
+static void print_digest(const char *name, int rc, const uint8_t digest[32]) {
+    printf("%s %d ", name, rc);
+    for (int i = 0; i < 32; i++) {
+        printf("%02x", digest[i]);
+    }
+    printf("\n");
+}
+
+int main(void) {
+    uint8_t elems[2 * 32] = {0};
+    uint8_t out[32] = {0};
+    const char *msg = "hello world";
+
+    elems[31] = 1; /* elements 1 and 2 */
+    elems[63] = 2;
+
+    print_digest("hash", poseidon_hash(elems, 2, out), out);
+    print_digest("hash_bytes", poseidon_hash_bytes((const uint8_t *)msg, strlen(msg), out), out);
+    print_digest("hash_bytes_strict", poseidon_hash_bytes_strict((const uint8_t *)msg, strlen(msg), out), out);
+    print_digest("hash_bytes_empty", poseidon_hash_bytes(NULL, 0, out), out);
+
+    memset(elems, 0xff, 32); /* not less than the field modulus */
+    printf("err_field %d\n", poseidon_hash(elems, 1, out));
+    printf("err_length %d\n", poseidon_hash(elems, 17, out));
+    printf("err_null %d\n", poseidon_hash_bytes(NULL, 1, out));
+
+    return 0;
+}
@@ -0,0 +1,38 @@
+/*
+ * poseidon.h - C ABI of the Poseidon hash (BN254 scalar field, circomlib constants).
+ *
+ * Build the library with:
+ *   go build -buildmode=c-shared -o libposeidon.so .
+ *
+ * Field elements and digests are 32-byte big-endian integers. All functions are thread-safe,
+ * do not retain the passed buffers and write `out` only on success.
+ */
+#ifndef POSEIDON_H
+#define POSEIDON_H
+
+#include <stddef.h>
+#include <stdint.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+#define POSEIDON_OK 0
+#define POSEIDON_ERR_NULL (-1)   /* NULL pointer argument */
+#define POSEIDON_ERR_LENGTH (-2) /* n is not in 1..16 (or the message is too long) */
+#define POSEIDON_ERR_FIELD (-3)  /* an element is not less than the field modulus */
+
+/* poseidon_hash hashes n (1..16) field elements stored as n consecutive 32-byte big-endian values. */
+int poseidon_hash(const uint8_t *elems, size_t n, uint8_t out[32]);
+
+/* poseidon_hash_bytes hashes len bytes with HashBytes (iden3-compatible legacy encoding). data may be NULL if len is 0. */
+int poseidon_hash_bytes(const uint8_t *data, size_t len, uint8_t out[32]);
+
+/* poseidon_hash_bytes_strict hashes len bytes with HashBytesStrict (length-prefixed, collision-resistant encoding). */
+int poseidon_hash_bytes_strict(const uint8_t *data, size_t len, uint8_t out[32]);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif /* POSEIDON_H */
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"poseidonAlgorithm/poseidon"
+)
+
+func TestCABIHelpers(t *testing.T) {
+	var out [32]byte
+
+	elems := make([]byte, 64)
+	elems[31], elems[63] = 1, 2
+
+	if rc := cabiHash(elems, 2, &out); rc != cabiOK {
+		t.Fatalf("cabiHash returned %d", rc)
+	}
+
+	if want := poseidon.Hash([]*big.Int{big.NewInt(1), big.NewInt(2)}); new(big.Int).SetBytes(out[:]).Cmp(want) != 0 {
+		t.Fatalf("digest is %x, expected %s", out, want)
+	}
+
+	for i := range elems[:32] {
+		elems[i] = 0xff // 2^256 - 1 > q
+	}
+	if rc := cabiHash(elems, 2, &out); rc != cabiErrField {
+		t.Fatalf("element outside the field returned %d", rc)
+	}
+
+	if rc := cabiHash(make([]byte, 17*32), 17, &out); rc != cabiErrLength {
+		t.Fatalf("17 elements returned %d", rc)
+	}
+
+	cabiHashBytes([]byte("abc"), true, &out)
+	if new(big.Int).SetBytes(out[:]).Cmp(poseidon.HashBytesStrict([]byte("abc"))) != 0 {
+		t.Fatalf("strict digest does not match HashBytesStrict")
+	}
+}
+
+// TestCABI - збирає libposeidon.so з -buildmode=c-shared і викликає її з програми на C (capi/example.c)
+func TestCABI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping c-shared build in short mode")
+	}
+
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("C compiler is not installed")
+	}
+
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "libposeidon.so")
+
+	build := exec.Command(filepath.Join(runtime.GOROOT(), "bin", "go"), "build", "-buildmode=c-shared", "-o", lib, ".")
+	build.Env = append(os.Environ(), "CGO_ENABLED=1")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("c-shared build failed: %v\n%s", err, out)
+	}
+
+	example := filepath.Join(dir, "example")
+	if out, err := exec.Command(cc, "-I", "capi", filepath.Join("capi", "example.c"),
+		"-L", dir, "-lposeidon", "-Wl,-rpath,"+dir, "-o", example).CombinedOutput(); err != nil {
+		t.Fatalf("cc failed: %v\n%s", err, out)
+	}
+
+	out, err := exec.Command(example).CombinedOutput()
+	if err != nil {
+		t.Fatalf("example failed: %v\n%s", err, out)
+	}
+
+	msg := []byte("hello world")
+	want := fmt.Sprintf("hash 0 %064x\nhash_bytes 0 %064x\nhash_bytes_strict 0 %064x\nhash_bytes_empty 0 %064x\n"+
+		"err_field -3\nerr_length -2\nerr_null -1\n",
+		poseidon.Hash([]*big.Int{big.NewInt(1), big.NewInt(2)}), poseidon.HashBytes(msg), poseidon.HashBytesStrict(msg), poseidon.HashBytes(nil))
+
+	if string(out) != want {
+		t.Fatalf("output is\n%s\nexpected\n%s", out, want)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !(js && wasm)
+
+// Команда poseidond - HTTP/JSON (і gRPC) сервіс гешування Poseidon: окрема програма з тими самими аргументами, що й
+// `poseidon serve`. Сервіс виконується в тому ж процесі функцією poseidon.RunServe.
+//
+//	go build -o bin/ ./cmd/poseidond
+//	bin/poseidond --addr :8080 --grpc-addr :9090
+package main
+
+import (
+	"context"
+	"os"
+
+	"poseidonAlgorithm/poseidon"
+)
+
+func main() {
+	os.Exit(poseidon.RunServe(context.Background(), os.Args[1:], os.Stderr))
+}
@@ -0,0 +1,78 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"poseidonAlgorithm/poseidon"
+)
+
+func TestPoseidond(t *testing.T) {
+	if code := poseidon.RunServe(context.Background(), []string{"--max-body", "0"}, io.Discard); code != 2 {
+		t.Fatalf("invalid arguments returned exit code %d", code)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	done := make(chan int, 1)
+
+	go func() {
+		done <- poseidon.RunServe(ctx, []string{"--addr", "127.0.0.1:0"}, pw)
+		pw.Close()
+	}()
+
+	addr := make(chan string, 1)
+	go func() { // адреса з журналу сервера; далі журнал лише вичитується, щоб сервер не блокувався на записі
+		scanner := bufio.NewScanner(pr)
+		for scanner.Scan() {
+			if _, a, ok := strings.Cut(scanner.Text(), "listening on "); ok {
+				addr <- a
+			}
+		}
+	}()
+
+	var url string
+	select {
+	case a := <-addr:
+		url = "http://" + a + "/v1/hash"
+	case <-time.After(30 * time.Second):
+		t.Fatal("server did not start")
+	}
+
+	resp, err := http.Post(url, "application/json", strings.NewReader(`{"elements": ["1", "2"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var body struct{ Hash string }
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d, %v", resp.StatusCode, err)
+	}
+
+	// вектор circomlibjs для Hash([1, 2])
+	if body.Hash != "7853200120776062878684798364095072458815029376092732009249414926327459813530" {
+		t.Fatalf("hash is %s", body.Hash)
+	}
+
+	cancel()
+
+	select {
+	case code := <-done:
+		if code != 0 {
+			t.Fatalf("poseidond exited with code %d", code)
+		}
+	case <-time.After(15 * time.Second):
+		t.Fatal("poseidond did not shut down")
+	}
+}
@@ -0,0 +1,78 @@
+package poseidongnark
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+// vector is a digest computed by Hash of the Go implementation.
+type vector struct {
+	Inputs []string `json:"inputs"`
+	Hash   string   `json:"hash"`
+}
+
+// defaultVectors are checked when POSEIDON_GNARK_VECTORS is not set (the checked-in gadget has width 3 only).
+var defaultVectors = []vector{
+	{[]string{"1", "2"}, "7853200120776062878684798364095072458815029376092732009249414926327459813530"},
+	{[]string{"0", "0"}, "14744269619966411208579211824598458697587494354926760081771325075741142829156"},
+}
+
+// hashCircuit asserts that Hash(Inputs) equals the public Digest.
+type hashCircuit struct {
+	Inputs []frontend.Variable
+	Digest frontend.Variable `gnark:",public"`
+}
+
+func (c *hashCircuit) Define(api frontend.API) error {
+	digest, err := Hash(api, c.Inputs...)
+	if err != nil {
+		return err
+	}
+
+	api.AssertIsEqual(digest, c.Digest)
+
+	return nil
+}
+
+// TestHash evaluates the gadget with the gnark test engine and compares it with the vectors
+// from the file named by POSEIDON_GNARK_VECTORS (written by TestGnarkGadget of the Go implementation).
+func TestHash(t *testing.T) {
+	vectors := defaultVectors
+
+	if path := os.Getenv("POSEIDON_GNARK_VECTORS"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := json.Unmarshal(data, &vectors); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, v := range vectors {
+		circuit := &hashCircuit{Inputs: make([]frontend.Variable, len(v.Inputs))}
+
+		assignment := &hashCircuit{Inputs: make([]frontend.Variable, len(v.Inputs)), Digest: v.Hash}
+		for i, x := range v.Inputs {
+			assignment.Inputs[i] = x
+		}
+
+		if err := test.IsSolved(circuit, assignment, ecc.BN254.ScalarField()); err != nil {
+			t.Fatalf("%d inputs: %v", len(v.Inputs), err)
+		}
+
+		wrong, _ := new(big.Int).SetString(v.Hash, 10)
+		assignment.Digest = wrong.Add(wrong, big.NewInt(1))
+
+		if err := test.IsSolved(circuit, assignment, ecc.BN254.ScalarField()); err == nil {
+			t.Fatalf("%d inputs: the circuit accepts a wrong digest", len(v.Inputs))
+		}
+	}
+}
@@ -1,10 +1,21 @@
 module poseidonAlgorithm
 
-go 1.19
+go 1.25.0
 
-require github.com/iden3/go-iden3-crypto v0.0.14
+require (
+	github.com/iden3/go-iden3-crypto v0.0.14
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.12
+)
+
+require (
+	github.com/dchest/blake512 v1.0.0 // indirect
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800 // indirect
+)
 
 require (
-	golang.org/x/crypto v0.8.0
-	golang.org/x/sys v0.7.0 // indirect
+	golang.org/x/crypto v0.54.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
 )
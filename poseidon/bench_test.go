@@ -0,0 +1,165 @@
+package poseidon
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+// Бенчмарки порівнюють реалізації пакета з go-iden3-crypto в під-бенчмарках impl=..., тому результати двох запусків
+// зручно порівнювати з benchstat (наприклад, benchstat -col /impl bench.txt для порівняння реалізацій).
+
+// benchInput - функція створення n елементів поля поблизу q (найгірший випадок для довжини чисел)
+func benchInput(n int) []*big.Int {
+	input := make([]*big.Int, n)
+	for i := range input {
+		input[i] = new(big.Int).Sub(q, big.NewInt(int64(i+1)))
+	}
+
+	return input
+}
+
+// benchElements - функція перетворення входу бенчмарку в масив Element
+func benchElements(input []*big.Int) []Element {
+	elems := make([]Element, len(input))
+	for i, x := range input {
+		elems[i], _ = NewElement(x)
+	}
+
+	return elems
+}
+
+func BenchmarkHash(b *testing.B) {
+	for n := 1; n <= INPUTS; n++ {
+		input := benchInput(n)
+		elems := benchElements(input)
+
+		b.Run(fmt.Sprintf("inputs=%d", n), func(b *testing.B) {
+			b.Run("impl=Hash", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					Hash(input)
+				}
+			})
+
+			b.Run("impl=Hasher", func(b *testing.B) {
+				h, dst := NewHasher(), new(big.Int)
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					h.Hash(dst, input)
+				}
+			})
+
+			b.Run("impl=HashConstantTime", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					HashConstantTime(elems...)
+				}
+			})
+
+			b.Run("impl=iden3", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					poseidon.Hash(input)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkHashBytes(b *testing.B) {
+	for _, size := range []int{SBLOCK, 64, SBLOCK * INPUTS, 1 << 10, 16 << 10} {
+		msg := testBytes(size)
+
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.Run("impl=HashBytes", func(b *testing.B) {
+				b.SetBytes(int64(size))
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					HashBytes(msg)
+				}
+			})
+
+			b.Run("impl=BytesHasher", func(b *testing.B) {
+				b.SetBytes(int64(size))
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					h := NewBytesHasher()
+					h.Write(msg)
+					h.Sum()
+				}
+			})
+
+			b.Run("impl=iden3", func(b *testing.B) {
+				b.SetBytes(int64(size))
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					poseidon.HashBytes(msg)
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkHashParallel - пропускна здатність гешування з GOMAXPROCS горутин (go test -bench Parallel -cpu 1,4,8)
+func BenchmarkHashParallel(b *testing.B) {
+	for _, n := range []int{2, INPUTS} {
+		input := benchInput(n)
+
+		b.Run(fmt.Sprintf("inputs=%d", n), func(b *testing.B) {
+			b.Run("impl=Hash", func(b *testing.B) {
+				b.ReportAllocs()
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						Hash(input)
+					}
+				})
+			})
+
+			b.Run("impl=Hasher", func(b *testing.B) {
+				b.ReportAllocs()
+				b.RunParallel(func(pb *testing.PB) {
+					h, dst := NewHasher(), new(big.Int) // Hasher - по одному на горутину
+					for pb.Next() {
+						h.Hash(dst, input)
+					}
+				})
+			})
+
+			b.Run("impl=iden3", func(b *testing.B) {
+				b.ReportAllocs()
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						poseidon.Hash(input)
+					}
+				})
+			})
+		})
+	}
+}
+
+func BenchmarkHashBytesParallel(b *testing.B) {
+	msg := testBytes(1 << 10)
+
+	b.Run("impl=HashBytes", func(b *testing.B) {
+		b.SetBytes(int64(len(msg)))
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				HashBytes(msg)
+			}
+		})
+	})
+
+	b.Run("impl=iden3", func(b *testing.B) {
+		b.SetBytes(int64(len(msg)))
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				poseidon.HashBytes(msg)
+			}
+		})
+	})
+}
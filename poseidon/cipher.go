@@ -0,0 +1,124 @@
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/iden3/go-iden3-crypto/babyjub"
+)
+
+// CIPHERRATE - кількість елементів повідомлення, які шифруються однією перестановкою (ширина стану 4 мінус ємність)
+const CIPHERRATE = 3
+
+var (
+	ErrInvalidNonce      = errors.New("poseidon: nonce must be less than 2^128")
+	ErrInvalidCiphertext = errors.New("poseidon: invalid ciphertext length")
+	ErrDecryptionFailed  = errors.New("poseidon: ciphertext authentication failed")
+)
+
+var two128 = new(big.Int).Lsh(big.NewInt(1), 128) // 2^128 - межа для nonce і множник довжини повідомлення
+
+// ECDHSharedKey - функція обчислення спільного ключа шифру з приватного ключа sk і публічного ключа pk на кривій Baby Jubjub;
+// ключем є координати точки sk*pk, як у genEcdhSharedKey з zk-kit/MACI
+func ECDHSharedKey(sk *babyjub.PrivateKey, pk *babyjub.PublicKey) [2]*big.Int {
+	p := new(babyjub.Point).Mul(sk.Scalar().BigInt(), pk.Point())
+
+	return [2]*big.Int{p.X, p.Y}
+}
+
+// cipherState - функція побудови початкового стану дуплексної губки [0, key[0], key[1], nonce + length*2^128]
+func cipherState(key [2]*big.Int, nonce *big.Int, length int) ([]*big.Int, error) {
+	if !inField(key[0]) || !inField(key[1]) {
+		return nil, ErrInvalidKey
+	}
+
+	if nonce == nil || nonce.Sign() < 0 || nonce.Cmp(two128) >= 0 {
+		return nil, ErrInvalidNonce
+	}
+
+	domain := new(big.Int).Mul(big.NewInt(int64(length)), two128)
+	domain.Add(domain, nonce)
+
+	return []*big.Int{big.NewInt(0), new(big.Int).Set(key[0]), new(big.Int).Set(key[1]), domain}, nil
+}
+
+// Encrypt - функція автентифікованого шифрування повідомлення msg дуплексною губкою Poseidon ширини 4
+// (за побудовою poseidonEncrypt з zk-kit poseidon-cipher; сумісність з zk-kit не підтверджена, її перевіряє
+// TestCipherUpstreamVectors з тегом upstream). Повідомлення доповнюється нулями до кратності CIPHERRATE,
+// шифротекст містить 3*ceil(len(msg)/3) елементів і останній елемент - тег автентифікації.
+func Encrypt(msg []*big.Int, key [2]*big.Int, nonce *big.Int) ([]*big.Int, error) {
+	for _, x := range msg {
+		if !inField(x) {
+			return nil, ErrInvalidInput
+		}
+	}
+
+	state, err := cipherState(key, nonce, len(msg))
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := (len(msg) + CIPHERRATE - 1) / CIPHERRATE
+	ciphertext := make([]*big.Int, 0, blocks*CIPHERRATE+1)
+
+	for i := 0; i < blocks; i++ {
+		state = permute(state)
+
+		for j := 0; j < CIPHERRATE; j++ {
+			if i*CIPHERRATE+j < len(msg) { // доповнюючі нульові елементи не змінюють стан
+				state[j+1].Add(state[j+1], msg[i*CIPHERRATE+j]).Mod(state[j+1], q)
+			}
+			ciphertext = append(ciphertext, new(big.Int).Set(state[j+1]))
+		}
+	}
+
+	state = permute(state)
+	ciphertext = append(ciphertext, new(big.Int).Set(state[1])) // тег автентифікації
+
+	return ciphertext, nil
+}
+
+// Decrypt - функція розшифрування шифротексту, отриманого функцією Encrypt (за побудовою poseidonDecrypt з zk-kit).
+// length - довжина вихідного повідомлення; якщо тег або доповнення не збігаються, повертається ErrDecryptionFailed.
+func Decrypt(ciphertext []*big.Int, key [2]*big.Int, nonce *big.Int, length int) ([]*big.Int, error) {
+	if length < 0 || len(ciphertext) != (length+CIPHERRATE-1)/CIPHERRATE*CIPHERRATE+1 {
+		return nil, ErrInvalidCiphertext
+	}
+
+	for _, x := range ciphertext {
+		if !inField(x) {
+			return nil, ErrInvalidInput
+		}
+	}
+
+	state, err := cipherState(key, nonce, length)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := make([]*big.Int, 0, len(ciphertext)-1)
+
+	for i := 0; i < len(ciphertext)/CIPHERRATE; i++ {
+		state = permute(state)
+
+		for j := 0; j < CIPHERRATE; j++ {
+			c := ciphertext[i*CIPHERRATE+j]
+			m := new(big.Int).Sub(c, state[j+1])
+			msg = append(msg, m.Mod(m, q))
+			state[j+1].Set(c)
+		}
+	}
+
+	for _, m := range msg[length:] { // доповнення повинно складатися з нулів
+		if m.Sign() != 0 {
+			return nil, ErrDecryptionFailed
+		}
+	}
+
+	state = permute(state)
+	if state[1].Cmp(ciphertext[len(ciphertext)-1]) != 0 {
+		return nil, ErrDecryptionFailed
+	}
+
+	return msg[:length], nil
+}
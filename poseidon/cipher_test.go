@@ -0,0 +1,128 @@
+package poseidon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/babyjub"
+)
+
+func testMessage(n int) []*big.Int {
+	msg := make([]*big.Int, n)
+	for i := range msg {
+		msg[i] = big.NewInt(int64(i*7 + 1))
+	}
+	return msg
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := [2]*big.Int{big.NewInt(123), big.NewInt(456)}
+	nonce := big.NewInt(5)
+
+	for n := 0; n <= 10; n++ {
+		msg := testMessage(n)
+
+		ciphertext, err := Encrypt(msg, key, nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := (n+2)/3*3 + 1; len(ciphertext) != want {
+			t.Fatalf("length %d: expected %d ciphertext elements, got %d", n, want, len(ciphertext))
+		}
+
+		plain, err := Decrypt(ciphertext, key, nonce, n)
+		if err != nil {
+			t.Fatalf("length %d: %v", n, err)
+		}
+
+		for i := range msg {
+			if plain[i].Cmp(msg[i]) != 0 {
+				t.Fatalf("length %d: element %d decrypted to %s, expected %s", n, i, plain[i], msg[i])
+			}
+		}
+	}
+}
+
+func TestEncryptDuplexStructure(t *testing.T) {
+	key := [2]*big.Int{big.NewInt(1), big.NewInt(2)}
+	nonce := big.NewInt(3)
+	msg := []*big.Int{big.NewInt(10), big.NewInt(20)}
+
+	ciphertext, err := Encrypt(msg, key, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// перший блок шифротексту - це стан після першої перестановки плюс повідомлення (з нулем доповнення)
+	domain := new(big.Int).Add(nonce, new(big.Int).Lsh(big.NewInt(int64(len(msg))), 128))
+	state := permute([]*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), domain})
+
+	for j, m := range []*big.Int{msg[0], msg[1], big.NewInt(0)} {
+		want := new(big.Int).Add(state[j+1], m)
+		want.Mod(want, q)
+		if ciphertext[j].Cmp(want) != 0 {
+			t.Fatalf("ciphertext element %d is %s, expected %s", j, ciphertext[j], want)
+		}
+		state[j+1] = want
+	}
+
+	if tag := permute(state)[1]; ciphertext[3].Cmp(tag) != 0 {
+		t.Fatalf("authentication tag is %s, expected %s", ciphertext[3], tag)
+	}
+}
+
+func TestDecryptRejectsTampering(t *testing.T) {
+	key := [2]*big.Int{big.NewInt(11), big.NewInt(22)}
+	nonce := big.NewInt(33)
+	msg := testMessage(4)
+
+	ciphertext, _ := Encrypt(msg, key, nonce)
+
+	tampered := append([]*big.Int{}, ciphertext...)
+	tampered[1] = new(big.Int).Add(tampered[1], big.NewInt(1))
+	if _, err := Decrypt(tampered, key, nonce, len(msg)); err != ErrDecryptionFailed {
+		t.Fatalf("expected ErrDecryptionFailed for modified ciphertext, got %v", err)
+	}
+
+	if _, err := Decrypt(ciphertext, key, big.NewInt(34), len(msg)); err != ErrDecryptionFailed {
+		t.Fatalf("expected ErrDecryptionFailed for wrong nonce, got %v", err)
+	}
+
+	if _, err := Decrypt(ciphertext, [2]*big.Int{big.NewInt(11), big.NewInt(23)}, nonce, len(msg)); err != ErrDecryptionFailed {
+		t.Fatalf("expected ErrDecryptionFailed for wrong key, got %v", err)
+	}
+
+	if _, err := Decrypt(ciphertext, key, nonce, 5); err != ErrDecryptionFailed {
+		t.Fatalf("expected ErrDecryptionFailed for wrong length, got %v", err)
+	}
+
+	if _, err := Decrypt(ciphertext, key, nonce, 7); err != ErrInvalidCiphertext {
+		t.Fatalf("expected ErrInvalidCiphertext, got %v", err)
+	}
+
+	if _, err := Encrypt(msg, key, two128); err != ErrInvalidNonce {
+		t.Fatalf("expected ErrInvalidNonce, got %v", err)
+	}
+}
+
+func TestECDHSharedKey(t *testing.T) {
+	alice := babyjub.NewRandPrivKey()
+	bob := babyjub.NewRandPrivKey()
+
+	k1 := ECDHSharedKey(&alice, bob.Public())
+	k2 := ECDHSharedKey(&bob, alice.Public())
+
+	if k1[0].Cmp(k2[0]) != 0 || k1[1].Cmp(k2[1]) != 0 {
+		t.Fatalf("shared keys differ: %s != %s", k1, k2)
+	}
+
+	ciphertext, err := Encrypt(testMessage(5), k1, big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Decrypt(ciphertext, k2, big.NewInt(0), 5); err != nil {
+		t.Fatal(err)
+	}
+}
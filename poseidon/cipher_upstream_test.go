@@ -0,0 +1,88 @@
+//go:build upstream
+
+// Сумісність Encrypt і Decrypt з zk-kit перевіряється лише з тегом upstream (go test -tags upstream), бо вектори
+// обчислює npm-пакет @zk-kit/poseidon-cipher.
+
+package poseidon
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+)
+
+// cipherVector - вектор шифру з testdata/upstream/zk-kit-cipher.json (числа - десяткові рядки)
+type cipherVector struct {
+	Key        [2]string `json:"key"`
+	Nonce      string    `json:"nonce"`
+	Plaintext  []string  `json:"plaintext"`
+	Ciphertext []string  `json:"ciphertext"`
+}
+
+// TestCipherUpstreamVectors - перевіряє Encrypt і Decrypt на векторах, обчислених poseidonEncrypt з
+// @zk-kit/poseidon-cipher (testdata/upstream/zk-kit-cipher.mjs). Без файла векторів тест падає.
+func TestCipherUpstreamVectors(t *testing.T) {
+	const path = "testdata/upstream/zk-kit-cipher.json"
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("%s is missing; generate it with testdata/upstream/zk-kit-cipher.mjs", path)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+
+	var file struct {
+		Source  string         `json:"source"`
+		Vectors []cipherVector `json:"vectors"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(file.Vectors) == 0 {
+		t.Fatalf("%s has no vectors", path)
+	}
+
+	decimals := func(values []string) []*big.Int {
+		out := make([]*big.Int, len(values))
+		for i, s := range values {
+			var ok bool
+			if out[i], ok = new(big.Int).SetString(s, 10); !ok {
+				t.Fatalf("%s: invalid number %q", path, s)
+			}
+		}
+		return out
+	}
+
+	for i, v := range file.Vectors {
+		k := decimals(v.Key[:])
+		key, nonce := [2]*big.Int{k[0], k[1]}, decimals([]string{v.Nonce})[0]
+		msg, want := decimals(v.Plaintext), decimals(v.Ciphertext)
+
+		ciphertext, err := Encrypt(msg, key, nonce)
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+
+		if len(ciphertext) != len(want) {
+			t.Fatalf("vector %d: %d ciphertext elements, %s returned %d", i, len(ciphertext), file.Source, len(want))
+		}
+		for j := range want {
+			if ciphertext[j].Cmp(want[j]) != 0 {
+				t.Fatalf("vector %d: ciphertext element %d is %s, %s returned %s", i, j, ciphertext[j], file.Source, want[j])
+			}
+		}
+
+		plain, err := Decrypt(want, key, nonce, len(msg))
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		for j := range msg {
+			if plain[j].Cmp(msg[j]) != 0 {
+				t.Fatalf("vector %d: element %d decrypted to %s, expected %s", i, j, plain[j], msg[j])
+			}
+		}
+	}
+}
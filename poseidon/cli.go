@@ -0,0 +1,508 @@
+//go:build !(js && wasm)
+
+package poseidon
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const usage = `Usage:
+  poseidon hash [--bytes] [--strict] [--hex|--dec] [FILE...]   hash files (or stdin) with HashBytes
+  poseidon hash --elems [--hex|--dec] [ELEMENT...]            hash 1..16 field elements (or read them from stdin) with Hash
+  poseidon hash --check [--strict] [FILE...]                  verify digests listed in FILE (or stdin)
+  poseidon serve [--addr :8080] [--grpc-addr :9090] [--max-body N] [--max-batch N]
+                                                              serve the HTTP/JSON (and gRPC) hashing API
+  poseidon trace [--format json|csv] ELEMENT...                print every intermediate round state of Hash
+  poseidon cost [--leaves N]                                  estimate R1CS/PLONK/AIR cost of each state width
+                                                              (and the cheapest Merkle arity for N leaves)
+  poseidon codegen --lang circom|gnark [--width 3,5] [--package NAME] [-o FILE]
+                                                              generate circom templates or a gnark gadget
+                                                              for the given state widths (default 2..17)
+
+Elements and digests are decimal or 0x-prefixed hexadecimal numbers.
+`
+
+// hashOptions - параметри команди hash
+type hashOptions struct {
+	elems  bool // гешувати елементи поля замість байтів
+	strict bool // використовувати HashBytesStrict замість HashBytes
+	hex    bool // виводити геш у шістнадцятковому вигляді
+	check  bool // перевіряти список гешів
+}
+
+// Run - функція виконання командного рядка poseidon з аргументами args (без назви програми); повертає код завершення
+// програми (0 - успіх, 1 - помилка гешування або перевірки, 2 - неправильні аргументи)
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "hash":
+		return runHash(args[1:], stdin, stdout, stderr)
+	case "serve":
+		return RunServe(context.Background(), args[1:], stderr)
+	case "codegen":
+		return runCodegen(args[1:], stdout, stderr)
+	case "trace":
+		return runTrace(args[1:], stdout, stderr)
+	case "cost":
+		return runCost(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+
+	fmt.Fprintf(stderr, "poseidon: unknown command %q\n%s", args[0], usage)
+	return 2
+}
+
+// runHash - функція команди hash
+func runHash(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var opts hashOptions
+	var bytesMode, dec bool
+
+	fs := flag.NewFlagSet("hash", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+	fs.BoolVar(&bytesMode, "bytes", false, "hash the contents of files (default)")
+	fs.BoolVar(&opts.elems, "elems", false, "hash field elements given as arguments")
+	fs.BoolVar(&opts.strict, "strict", false, "use HashBytesStrict instead of HashBytes")
+	fs.BoolVar(&opts.hex, "hex", false, "print digests as 0x-prefixed hexadecimal")
+	fs.BoolVar(&dec, "dec", false, "print digests as decimal (default)")
+	fs.BoolVar(&opts.check, "check", false, "verify digests from the given lists")
+
+	positional, err := parseInterleaved(fs, args)
+	if err != nil {
+		return 2
+	}
+
+	if (bytesMode && opts.elems) || (opts.hex && dec) || (opts.check && opts.elems) || (opts.strict && opts.elems) {
+		fmt.Fprintf(stderr, "poseidon: conflicting flags\n%s", usage)
+		return 2
+	}
+
+	switch {
+	case opts.elems:
+		return hashElements(positional, opts, stdin, stdout, stderr)
+	case opts.check:
+		return checkDigests(positional, opts, stdin, stdout, stderr)
+	}
+
+	files := positional
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	status := 0
+
+	for _, name := range files {
+		hash, err := hashFile(name, opts, stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "poseidon: %v\n", err)
+			status = 1
+			continue
+		}
+
+		fmt.Fprintf(stdout, "%s  %s\n", formatDigest(hash, opts.hex), name)
+	}
+
+	return status
+}
+
+// runCodegen - функція команди codegen
+func runCodegen(args []string, stdout, stderr io.Writer) int {
+	var lang, widthList, pkg, output string
+
+	fs := flag.NewFlagSet("codegen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+	fs.StringVar(&lang, "lang", "", "target language: circom or gnark")
+	fs.StringVar(&widthList, "width", "", "comma-separated state widths 2..17 (default all)")
+	fs.StringVar(&pkg, "package", "poseidongnark", "Go package name of the gnark gadget")
+	fs.StringVar(&output, "o", "", "output file (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() > 0 || (lang != "circom" && lang != "gnark") {
+		fmt.Fprintf(stderr, "poseidon: codegen expects --lang circom or --lang gnark\n%s", usage)
+		return 2
+	}
+
+	var widths []int
+	if widthList != "" {
+		for _, field := range strings.Split(widthList, ",") {
+			t, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil || t < 2 || t > INPUTS+1 {
+				fmt.Fprintf(stderr, "poseidon: invalid state width %q (expected 2..%d)\n", field, INPUTS+1)
+				return 2
+			}
+			widths = append(widths, t)
+		}
+	}
+
+	var buf bytes.Buffer
+	var err error
+
+	if lang == "circom" {
+		err = GenerateCircom(&buf, widths...)
+	} else {
+		err = GenerateGnark(&buf, pkg, widths...)
+	}
+
+	if err == nil {
+		if output == "" {
+			_, err = stdout.Write(buf.Bytes())
+		} else {
+			err = os.WriteFile(output, buf.Bytes(), 0o644)
+		}
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "poseidon: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// runTrace - функція команди trace
+func runTrace(args []string, stdout, stderr io.Writer) int {
+	var format string
+
+	fs := flag.NewFlagSet("trace", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+	fs.StringVar(&format, "format", "json", "output format: json or csv")
+
+	positional, err := parseInterleaved(fs, args)
+	if err != nil {
+		return 2
+	}
+
+	if format != "json" && format != "csv" {
+		fmt.Fprintf(stderr, "poseidon: unknown trace format %q\n", format)
+		return 2
+	}
+
+	if len(positional) == 0 || len(positional) > INPUTS {
+		fmt.Fprintf(stderr, "poseidon: expected 1..%d elements, got %d\n", INPUTS, len(positional))
+		return 2
+	}
+
+	input := make([]*big.Int, len(positional))
+
+	for i, arg := range positional {
+		x, err := parseElement(arg)
+		if err != nil {
+			fmt.Fprintf(stderr, "poseidon: element %d: %v\n", i+1, err)
+			return 2
+		}
+		input[i] = x
+	}
+
+	tr, err := HashTrace(input)
+	if err == nil {
+		if format == "csv" {
+			err = tr.WriteCSV(stdout)
+		} else {
+			err = tr.WriteJSON(stdout)
+		}
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "poseidon: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// runCost - функція команди cost: таблиця оцінок EstimateCost для ширин 2..17 і, якщо задано --leaves,
+// найдешевша арність дерева Меркла для кожної метрики
+func runCost(args []string, stdout, stderr io.Writer) int {
+	var leaves int
+
+	fs := flag.NewFlagSet("cost", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+	fs.IntVar(&leaves, "leaves", 0, "number of Merkle tree leaves")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() > 0 || leaves < 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "width\tinputs\tRF\tRP\tS-boxes\tR1CS\tPLONK\tPLONK+x^5\tAIR cells\t")
+
+	for width := 2; width <= INPUTS+1; width++ {
+		c, _ := EstimateCost(width)
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t\n",
+			c.Width, c.Width-1, c.NRoundsF, c.NRoundsP, c.SBoxes, c.R1CS, c.PlonkGates, c.PlonkCustomGates, c.AIRCells)
+	}
+
+	tw.Flush()
+
+	if leaves == 0 {
+		return 0
+	}
+
+	fmt.Fprintf(stdout, "\nCheapest Merkle arity for a membership proof over %d leaves:\n", leaves)
+
+	for _, m := range []struct {
+		name   string
+		metric func(CircuitCost) int
+	}{
+		{"R1CS", func(c CircuitCost) int { return c.R1CS }},
+		{"PLONK", func(c CircuitCost) int { return c.PlonkGates }},
+		{"PLONK+x^5", func(c CircuitCost) int { return c.PlonkCustomGates }},
+		{"AIR cells", func(c CircuitCost) int { return c.AIRCells }},
+	} {
+		arity, total, err := CheapestMerkleArity(leaves, m.metric)
+		if err != nil {
+			fmt.Fprintf(stderr, "poseidon: %v\n", err)
+			return 1
+		}
+
+		fmt.Fprintf(stdout, "  %-10s arity %d, depth %d, %d per proof\n", m.name, arity, merkleDepth(arity, leaves), total)
+	}
+
+	return 0
+}
+
+// parseInterleaved - функція розбору прапорців, які можуть стояти як до, так і після позиційних аргументів
+// (після "--" всі аргументи вважаються позиційними); повертає позиційні аргументи
+func parseInterleaved(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// hashElements - функція гешування елементів поля з аргументів (або зі стандартного входу, якщо аргументів немає)
+func hashElements(args []string, opts hashOptions, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		scanner := bufio.NewScanner(stdin)
+		scanner.Split(bufio.ScanWords)
+
+		for scanner.Scan() {
+			args = append(args, scanner.Text())
+		}
+
+		if err := scanner.Err(); err != nil {
+			fmt.Fprintf(stderr, "poseidon: %v\n", err)
+			return 1
+		}
+	}
+
+	if len(args) == 0 || len(args) > INPUTS {
+		fmt.Fprintf(stderr, "poseidon: expected 1..%d elements, got %d\n", INPUTS, len(args))
+		return 2
+	}
+
+	input := make([]*big.Int, len(args))
+
+	for i, arg := range args {
+		x, err := parseElement(arg)
+		if err != nil {
+			fmt.Fprintf(stderr, "poseidon: element %d: %v\n", i+1, err)
+			return 2
+		}
+		input[i] = x
+	}
+
+	fmt.Fprintln(stdout, formatDigest(Hash(input), opts.hex))
+
+	return 0
+}
+
+// checkDigests - функція перевірки списків у форматі "<геш>  <файл>" (як у sha256sum --check)
+func checkDigests(lists []string, opts hashOptions, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(lists) == 0 {
+		lists = []string{"-"}
+	}
+
+	failed, malformed := 0, 0
+
+	for _, list := range lists {
+		r, closeList, err := openInput(list, stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "poseidon: %v\n", err)
+			return 1
+		}
+
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+
+			digest, name, ok := parseCheckLine(line)
+			if !ok {
+				malformed++
+				continue
+			}
+
+			hash, err := hashFile(name, opts, stdin)
+			if err != nil {
+				fmt.Fprintf(stdout, "%s: FAILED open or read\n", name)
+				failed++
+				continue
+			}
+
+			if hash.Cmp(digest) != 0 {
+				fmt.Fprintf(stdout, "%s: FAILED\n", name)
+				failed++
+				continue
+			}
+
+			fmt.Fprintf(stdout, "%s: OK\n", name)
+		}
+
+		err = scanner.Err()
+		closeList()
+
+		if err != nil {
+			fmt.Fprintf(stderr, "poseidon: %s: %v\n", list, err)
+			return 1
+		}
+	}
+
+	if malformed > 0 {
+		fmt.Fprintf(stderr, "poseidon: WARNING: %d line(s) are improperly formatted\n", malformed)
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(stderr, "poseidon: WARNING: %d computed digest(s) did NOT match\n", failed)
+	}
+
+	if failed > 0 || malformed > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// parseCheckLine - функція розбору рядка "<геш>  <файл>" або "<геш> *<файл>"
+func parseCheckLine(line string) (*big.Int, string, bool) {
+	i := strings.IndexByte(line, ' ')
+	if i <= 0 || i+2 > len(line) || (line[i+1] != ' ' && line[i+1] != '*') {
+		return nil, "", false
+	}
+
+	digest, err := parseElement(line[:i])
+	if err != nil {
+		return nil, "", false
+	}
+
+	return digest, line[i+2:], true
+}
+
+// hashFile - функція гешування вмісту файлу name ("-" - стандартний вхід) потоковим гешером BytesHasher.
+// Для --strict довжина потрібна наперед: її дає розмір звичайного файлу, а вхід невідомої довжини (канал) читається
+// в пам'ять повністю.
+func hashFile(name string, opts hashOptions, stdin io.Reader) (*big.Int, error) {
+	r, closeFile, err := openInput(name, stdin)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile()
+
+	h := NewBytesHasher()
+	if opts.strict {
+		size, ok := inputSize(r)
+		if !ok {
+			msg, err := io.ReadAll(r)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			return HashBytesStrict(msg), nil
+		}
+
+		h = NewStrictBytesHasher(size)
+	}
+
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	hash, err := h.Sum()
+	if err != nil { // розмір файлу змінився під час читання
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return hash, nil
+}
+
+// inputSize - функція розміру входу r, якщо це звичайний файл
+func inputSize(r io.Reader) (uint64, bool) {
+	f, ok := r.(*os.File)
+	if !ok {
+		return 0, false
+	}
+
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return 0, false
+	}
+
+	return uint64(info.Size()), true
+}
+
+// openInput - функція відкриття файлу name або стандартного входу, якщо name дорівнює "-"
+func openInput(name string, stdin io.Reader) (io.Reader, func(), error) {
+	if name == "-" {
+		return stdin, func() {}, nil
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return f, func() { f.Close() }, nil
+}
+
+// formatDigest - функція форматування гешу в десятковому або шістнадцятковому (64 цифри з префіксом 0x) вигляді
+func formatDigest(x *big.Int, hex bool) string {
+	if hex {
+		return fmt.Sprintf("0x%064x", x)
+	}
+
+	return x.String()
+}
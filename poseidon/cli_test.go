@@ -0,0 +1,205 @@
+//go:build !(js && wasm)
+
+package poseidon
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// runCLI - функція запуску командного рядка з заданим стандартним входом; повертає код завершення, stdout і stderr
+func runCLI(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := Run(args, strings.NewReader(stdin), &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func writeTestFile(t *testing.T, dir, name string, data []byte) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCLIHashFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := writeTestFile(t, dir, "a.bin", []byte("hello world"))
+	b := writeTestFile(t, dir, "b.bin", testBytes(500))
+
+	code, out, _ := runCLI("", "hash", "--bytes", a, b)
+	if code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+
+	want := fmt.Sprintf("%s  %s\n%s  %s\n", HashBytes([]byte("hello world")), a, HashBytes(testBytes(500)), b)
+	if out != want {
+		t.Fatalf("output is %q, expected %q", out, want)
+	}
+
+	code, out, _ = runCLI("hello world", "hash", "--hex")
+	if code != 0 || out != fmt.Sprintf("0x%064x  -\n", HashBytes([]byte("hello world"))) {
+		t.Fatalf("stdin hash: exit code %d, output %q", code, out)
+	}
+
+	code, out, _ = runCLI("hello world", "hash", "-", "--strict")
+	if code != 0 || out != fmt.Sprintf("%s  -\n", HashBytesStrict([]byte("hello world"))) {
+		t.Fatalf("strict hash: exit code %d, output %q", code, out)
+	}
+
+	code, out, _ = runCLI("", "hash", "--strict", b)
+	if code != 0 || out != fmt.Sprintf("%s  %s\n", HashBytesStrict(testBytes(500)), b) {
+		t.Fatalf("strict file hash: exit code %d, output %q", code, out)
+	}
+
+	code, _, errOut := runCLI("", "hash", filepath.Join(dir, "missing"))
+	if code != 1 || !strings.Contains(errOut, "missing") {
+		t.Fatalf("missing file: exit code %d, stderr %q", code, errOut)
+	}
+}
+
+func TestCLIHashElements(t *testing.T) {
+	want := Hash([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+
+	code, out, _ := runCLI("", "hash", "--elems", "1", "0x2", "3")
+	if code != 0 || out != want.String()+"\n" {
+		t.Fatalf("exit code %d, output %q", code, out)
+	}
+
+	code, out, _ = runCLI("1 2\n3\n", "hash", "--elems", "--hex")
+	if code != 0 || out != fmt.Sprintf("0x%064x\n", want) {
+		t.Fatalf("stdin elements: exit code %d, output %q", code, out)
+	}
+
+	for _, args := range [][]string{
+		{"hash", "--elems", "abc"},
+		{"hash", "--elems", q.String()},
+		{"hash", "--elems", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17"},
+		{"hash", "--elems", "--bytes", "1"},
+		{"hash", "--hex", "--dec"},
+		{"hash", "--unknown"},
+		{"unknown"},
+		{},
+	} {
+		if code, _, _ := runCLI("", args...); code != 2 {
+			t.Fatalf("%q: expected exit code 2, got %d", args, code)
+		}
+	}
+}
+
+func TestCLICheck(t *testing.T) {
+	dir := t.TempDir()
+	a := writeTestFile(t, dir, "a.bin", []byte("first"))
+	b := writeTestFile(t, dir, "b.bin", []byte("second"))
+
+	_, sums, _ := runCLI("", "hash", "--hex", a, b)
+	list := writeTestFile(t, dir, "sums.txt", []byte(sums))
+
+	code, out, _ := runCLI("", "hash", "--check", list)
+	if code != 0 || out != fmt.Sprintf("%s: OK\n%s: OK\n", a, b) {
+		t.Fatalf("exit code %d, output %q", code, out)
+	}
+
+	writeTestFile(t, dir, "b.bin", []byte("changed"))
+
+	code, out, errOut := runCLI(sums+"not a digest line\n", "hash", "--check")
+	if code != 1 || !strings.Contains(out, b+": FAILED") || !strings.Contains(out, a+": OK") {
+		t.Fatalf("exit code %d, output %q", code, out)
+	}
+	if !strings.Contains(errOut, "1 line(s) are improperly formatted") || !strings.Contains(errOut, "1 computed digest(s) did NOT match") {
+		t.Fatalf("stderr is %q", errOut)
+	}
+}
+
+func TestCLICodegen(t *testing.T) {
+	var circom bytes.Buffer
+	if err := GenerateCircom(&circom, 3, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	code, out, _ := runCLI("", "codegen", "--lang", "circom", "--width", "3, 5")
+	if code != 0 || out != circom.String() {
+		t.Fatalf("circom: exit code %d, output differs from GenerateCircom", code)
+	}
+
+	file := filepath.Join(t.TempDir(), "poseidon.go")
+
+	code, _, _ = runCLI("", "codegen", "--lang", "gnark", "--width", "3", "--package", "gadget", "-o", file)
+	if data, _ := os.ReadFile(file); code != 0 || !bytes.Contains(data, []byte("package gadget\n")) {
+		t.Fatalf("gnark: exit code %d", code)
+	}
+
+	for _, args := range [][]string{{"codegen"}, {"codegen", "--lang", "noir"}, {"codegen", "--lang", "circom", "--width", "18"}} {
+		if code, _, _ := runCLI("", args...); code != 2 {
+			t.Fatalf("%v: exit code %d, expected 2", args, code)
+		}
+	}
+}
+
+func TestCLITrace(t *testing.T) {
+	tr, _ := HashTrace(ints(1, 2))
+
+	var want bytes.Buffer
+	tr.WriteCSV(&want)
+
+	code, out, _ := runCLI("", "trace", "--format", "csv", "1", "0x2")
+	if code != 0 || out != want.String() {
+		t.Fatalf("csv: exit code %d, output differs from WriteCSV", code)
+	}
+
+	want.Reset()
+	tr.WriteJSON(&want)
+
+	if code, out, _ := runCLI("", "trace", "1", "2"); code != 0 || out != want.String() {
+		t.Fatalf("json: exit code %d, output differs from WriteJSON", code)
+	}
+
+	for _, args := range [][]string{{"trace"}, {"trace", "--format", "xml", "1"}, {"trace", "abc"}} {
+		if code, _, _ := runCLI("", args...); code != 2 {
+			t.Fatalf("%v: exit code %d, expected 2", args, code)
+		}
+	}
+}
+
+func TestCLICost(t *testing.T) {
+	code, out, _ := runCLI("", "cost", "--leaves", "1000")
+	if code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+
+	cost, _ := EstimateCost(3)
+	row := fmt.Sprint(cost.Width, cost.Width-1, cost.NRoundsF, cost.NRoundsP, cost.SBoxes, cost.R1CS, cost.PlonkGates, cost.PlonkCustomGates, cost.AIRCells)
+
+	found := false
+	for _, line := range strings.Split(out, "\n") {
+		found = found || strings.Join(strings.Fields(line), " ") == row
+	}
+	if !found {
+		t.Fatalf("output does not contain the width 3 row %q:\n%s", row, out)
+	}
+
+	arity, total, _ := CheapestMerkleArity(1000, func(c CircuitCost) int { return c.R1CS })
+	if want := fmt.Sprintf("R1CS       arity %d, depth %d, %d per proof", arity, merkleDepth(arity, 1000), total); !strings.Contains(out, want) {
+		t.Fatalf("output does not contain %q:\n%s", want, out)
+	}
+
+	if code, _, _ := runCLI("", "cost", "--leaves", "-1"); code != 2 {
+		t.Fatalf("negative leaves: exit code %d", code)
+	}
+
+	code, out, _ = runCLI("", "cost", "--leaves", strconv.Itoa(math.MaxInt))
+	if code != 0 {
+		t.Fatalf("MaxInt leaves: exit code %d", code)
+	}
+	if arity, _, _ := CheapestMerkleArity(math.MaxInt, func(c CircuitCost) int { return c.R1CS }); !strings.Contains(out, fmt.Sprintf("R1CS       arity %d, depth %d,", arity, merkleDepth(arity, math.MaxInt))) {
+		t.Fatalf("MaxInt leaves: unexpected output:\n%s", out)
+	}
+}
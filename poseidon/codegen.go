@@ -0,0 +1,495 @@
+package poseidon
+
+import (
+	"bytes"
+	"go/format"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:generate go run .. codegen --lang gnark --width 3 -o ../gnark/poseidon.go
+//go:generate go run .. codegen --lang circom --width 3 -o testdata/poseidon_t3.circom
+
+// codegenWidth - параметри однієї ширини стану для шаблонів генерації коду схем
+type codegenWidth struct {
+	T        int      // ширина стану
+	Inputs   int      // кількість входів (T-1)
+	NRoundsF int      // кількість повних раундів
+	NRoundsP int      // кількість часткових раундів
+	C, S     []string // константи раундів і розріджені матриці часткових раундів (десяткові)
+	M, P     [][]string
+}
+
+// codegenWidths - функція підготовки констант для заданих ширин стану (без повторів, у порядку зростання);
+// якщо ширини не задані, використовуються всі ширини 2..17. Повертає ErrInputsLength для ширини поза 2..17.
+func codegenWidths(widths []int) ([]codegenWidth, error) {
+	if len(widths) == 0 {
+		for t := 2; t <= INPUTS+1; t++ {
+			widths = append(widths, t)
+		}
+	}
+
+	selected := make([]bool, INPUTS+2)
+	for _, t := range widths {
+		if t < 2 || t > INPUTS+1 {
+			return nil, ErrInputsLength
+		}
+		selected[t] = true
+	}
+
+	decimal := func(src []*big.Int) []string {
+		dst := make([]string, len(src))
+		for i, x := range src {
+			dst[i] = x.String()
+		}
+		return dst
+	}
+
+	matrix := func(src [][]*big.Int) [][]string {
+		dst := make([][]string, len(src))
+		for i, row := range src {
+			dst[i] = decimal(row)
+		}
+		return dst
+	}
+
+	var out []codegenWidth
+	for t := 2; t <= INPUTS+1; t++ {
+		if selected[t] {
+			out = append(out, codegenWidth{
+				T:        t,
+				Inputs:   t - 1,
+				NRoundsF: NROUNDSF,
+				NRoundsP: NROUNDSP[t-2],
+				C:        decimal(c.c[t-2]),
+				S:        decimal(c.s[t-2]),
+				M:        matrix(c.m[t-2]),
+				P:        matrix(c.p[t-2]),
+			})
+		}
+	}
+
+	return out, nil
+}
+
+// codegenFuncs - допоміжні функції шаблонів: list - значення по одному в рядку з відступом, inline - значення в один
+// рядок через кому, quote - значення в лапках (рядкові літерали Go)
+var codegenFuncs = template.FuncMap{
+	"list": func(values []string, indent string) string {
+		return indent + strings.Join(values, ",\n"+indent)
+	},
+	"inline": func(values []string) string {
+		return strings.Join(values, ", ")
+	},
+	"quote": func(values []string) []string {
+		out := make([]string, len(values))
+		for i, v := range values {
+			out[i] = strconv.Quote(v)
+		}
+		return out
+	},
+}
+
+// GenerateCircom - функція генерації circom-шаблонів PoseidonT{t} для заданих ширин стану (всіх ширин 2..17, якщо
+// ширини не задані). Шаблони мають ту саму структуру раундів, що й poseidon.circom з circomlib, і константи C, S, M, P
+// з constants.go, тому вихід out шаблону PoseidonT{t} дорівнює Hash(inputs) для t-1 входів.
+func GenerateCircom(w io.Writer, widths ...int) error {
+	params, err := codegenWidths(widths)
+	if err != nil {
+		return err
+	}
+
+	return circomTemplate.Execute(w, params)
+}
+
+// GenerateGnark - функція генерації гаджета gnark (frontend.API) у пакеті pkg для заданих ширин стану (всіх ширин 2..17,
+// якщо ширини не задані). Згенерована функція Hash(api, inputs...) обчислює в схемі той самий геш, що й Hash.
+func GenerateGnark(w io.Writer, pkg string, widths ...int) error {
+	params, err := codegenWidths(widths)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := gnarkTemplate.Execute(&buf, struct {
+		Package string
+		Widths  []codegenWidth
+	}{pkg, params}); err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes()) // вирівнювання як у gofmt
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(src)
+
+	return err
+}
+
+var circomTemplate = template.Must(template.New("circom").Funcs(codegenFuncs).Parse(`// Code generated by "poseidon codegen"; DO NOT EDIT.
+//
+// Poseidon over the BN254 scalar field with circomlib constants. PoseidonT{t} hashes t-1 inputs and
+// computes the same digest as Hash from the Go implementation (and Poseidon(t-1) from circomlib).
+
+pragma circom 2.0.0;
+
+template PoseidonSigma() {
+    signal input in;
+    signal output out;
+
+    signal in2;
+    signal in4;
+
+    in2 <== in*in;
+    in4 <== in2*in2;
+
+    out <== in4*in;
+}
+
+template PoseidonArk(t, C, r) {
+    signal input in[t];
+    signal output out[t];
+
+    for (var i=0; i<t; i++) {
+        out[i] <== in[i] + C[i + r];
+    }
+}
+
+template PoseidonMix(t, M) {
+    signal input in[t];
+    signal output out[t];
+
+    var lc;
+    for (var i=0; i<t; i++) {
+        lc = 0;
+        for (var j=0; j<t; j++) {
+            lc += M[j][i]*in[j];
+        }
+        out[i] <== lc;
+    }
+}
+
+template PoseidonMixLast(t, M, s) {
+    signal input in[t];
+    signal output out;
+
+    var lc = 0;
+    for (var j=0; j<t; j++) {
+        lc += M[j][s]*in[j];
+    }
+    out <== lc;
+}
+
+template PoseidonMixS(t, S, r) {
+    signal input in[t];
+    signal output out[t];
+
+    var lc = 0;
+    for (var i=0; i<t; i++) {
+        lc += S[(t*2-1)*r+i]*in[i];
+    }
+    out[0] <== lc;
+    for (var i=1; i<t; i++) {
+        out[i] <== in[i] + in[0] * S[(t*2-1)*r + t + i - 1];
+    }
+}
+
+template PoseidonRounds(t, nRoundsF, nRoundsP, C, S, M, P) {
+    signal input inputs[t-1];
+    signal output out;
+
+    component ark[nRoundsF];
+    component sigmaF[nRoundsF][t];
+    component sigmaP[nRoundsP];
+    component mix[nRoundsF-1];
+    component mixS[nRoundsP];
+    component mixLast;
+
+    ark[0] = PoseidonArk(t, C, 0);
+    ark[0].in[0] <== 0;
+    for (var j=1; j<t; j++) {
+        ark[0].in[j] <== inputs[j-1];
+    }
+
+    for (var r = 0; r < nRoundsF\2-1; r++) {
+        for (var j=0; j<t; j++) {
+            sigmaF[r][j] = PoseidonSigma();
+            if (r==0) {
+                sigmaF[r][j].in <== ark[0].out[j];
+            } else {
+                sigmaF[r][j].in <== mix[r-1].out[j];
+            }
+        }
+
+        ark[r+1] = PoseidonArk(t, C, (r+1)*t);
+        for (var j=0; j<t; j++) {
+            ark[r+1].in[j] <== sigmaF[r][j].out;
+        }
+
+        mix[r] = PoseidonMix(t, M);
+        for (var j=0; j<t; j++) {
+            mix[r].in[j] <== ark[r+1].out[j];
+        }
+    }
+
+    for (var j=0; j<t; j++) {
+        sigmaF[nRoundsF\2-1][j] = PoseidonSigma();
+        sigmaF[nRoundsF\2-1][j].in <== mix[nRoundsF\2-2].out[j];
+    }
+
+    ark[nRoundsF\2] = PoseidonArk(t, C, (nRoundsF\2)*t);
+    for (var j=0; j<t; j++) {
+        ark[nRoundsF\2].in[j] <== sigmaF[nRoundsF\2-1][j].out;
+    }
+
+    mix[nRoundsF\2-1] = PoseidonMix(t, P);
+    for (var j=0; j<t; j++) {
+        mix[nRoundsF\2-1].in[j] <== ark[nRoundsF\2].out[j];
+    }
+
+    for (var r = 0; r < nRoundsP; r++) {
+        sigmaP[r] = PoseidonSigma();
+        if (r==0) {
+            sigmaP[r].in <== mix[nRoundsF\2-1].out[0];
+        } else {
+            sigmaP[r].in <== mixS[r-1].out[0];
+        }
+
+        mixS[r] = PoseidonMixS(t, S, r);
+        for (var j=0; j<t; j++) {
+            if (j==0) {
+                mixS[r].in[j] <== sigmaP[r].out + C[(nRoundsF\2+1)*t + r];
+            } else if (r==0) {
+                mixS[r].in[j] <== mix[nRoundsF\2-1].out[j];
+            } else {
+                mixS[r].in[j] <== mixS[r-1].out[j];
+            }
+        }
+    }
+
+    for (var r = 0; r < nRoundsF\2-1; r++) {
+        for (var j=0; j<t; j++) {
+            sigmaF[nRoundsF\2 + r][j] = PoseidonSigma();
+            if (r==0) {
+                sigmaF[nRoundsF\2 + r][j].in <== mixS[nRoundsP-1].out[j];
+            } else {
+                sigmaF[nRoundsF\2 + r][j].in <== mix[nRoundsF\2 + r - 1].out[j];
+            }
+        }
+
+        ark[nRoundsF\2 + r + 1] = PoseidonArk(t, C, (nRoundsF\2+1)*t + nRoundsP + r*t);
+        for (var j=0; j<t; j++) {
+            ark[nRoundsF\2 + r + 1].in[j] <== sigmaF[nRoundsF\2 + r][j].out;
+        }
+
+        mix[nRoundsF\2 + r] = PoseidonMix(t, M);
+        for (var j=0; j<t; j++) {
+            mix[nRoundsF\2 + r].in[j] <== ark[nRoundsF\2 + r + 1].out[j];
+        }
+    }
+
+    for (var j=0; j<t; j++) {
+        sigmaF[nRoundsF-1][j] = PoseidonSigma();
+        sigmaF[nRoundsF-1][j].in <== mix[nRoundsF-2].out[j];
+    }
+
+    mixLast = PoseidonMixLast(t, M, 0);
+    for (var j=0; j<t; j++) {
+        mixLast.in[j] <== sigmaF[nRoundsF-1][j].out;
+    }
+
+    out <== mixLast.out;
+}
+{{range .}}
+template PoseidonT{{.T}}() {
+    signal input inputs[{{.Inputs}}];
+    signal output out;
+
+    var C[{{len .C}}] = [
+{{list .C "        "}}
+    ];
+
+    var S[{{len .S}}] = [
+{{list .S "        "}}
+    ];
+
+    var M[{{.T}}][{{.T}}] = [
+{{range $i, $row := .M}}{{if $i}},
+{{end}}        [{{inline $row}}]{{end}}
+    ];
+
+    var P[{{.T}}][{{.T}}] = [
+{{range $i, $row := .P}}{{if $i}},
+{{end}}        [{{inline $row}}]{{end}}
+    ];
+
+    component rounds = PoseidonRounds({{.T}}, {{.NRoundsF}}, {{.NRoundsP}}, C, S, M, P);
+    for (var j=0; j<{{.Inputs}}; j++) {
+        rounds.inputs[j] <== inputs[j];
+    }
+
+    out <== rounds.out;
+}
+{{end}}`))
+
+var gnarkTemplate = template.Must(template.New("gnark").Funcs(codegenFuncs).Parse(`// Code generated by "poseidon codegen"; DO NOT EDIT.
+
+// Package {{.Package}} is a gnark (frontend.API) gadget for the Poseidon hash over the BN254 scalar field
+// with circomlib constants. Hash computes the same digest as Hash from the Go implementation.
+package {{.Package}}
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// params holds the round counts and constants of one state width.
+type params struct {
+	nRoundsF, nRoundsP int
+	c, s               []*big.Int
+	m, p               [][]*big.Int
+}
+
+// Hash returns the Poseidon digest of the inputs. Only the state widths (len(inputs)+1) this file
+// was generated for are available.
+func Hash(api frontend.API, inputs ...frontend.Variable) (frontend.Variable, error) {
+	p, ok := widths[len(inputs)+1]
+	if !ok {
+		return nil, fmt.Errorf("poseidon: no constants generated for %d inputs", len(inputs))
+	}
+
+	state := make([]frontend.Variable, len(inputs)+1)
+	state[0] = 0
+	copy(state[1:], inputs)
+
+	return permute(api, p, state)[0], nil
+}
+
+// permute applies the optimized Poseidon permutation (full rounds with M and P, partial rounds with the sparse S).
+func permute(api frontend.API, p *params, state []frontend.Variable) []frontend.Variable {
+	t := len(state)
+
+	addRoundKeys(api, state, p.c, 0)
+
+	for i := 0; i < p.nRoundsF/2-1; i++ {
+		exp5State(api, state)
+		addRoundKeys(api, state, p.c, (i+1)*t)
+		state = mix(api, state, p.m)
+	}
+
+	exp5State(api, state)
+	addRoundKeys(api, state, p.c, (p.nRoundsF/2)*t)
+	state = mix(api, state, p.p)
+
+	for i := 0; i < p.nRoundsP; i++ {
+		row := p.s[(2*t-1)*i : (2*t-1)*(i+1)]
+
+		state[0] = api.Add(exp5(api, state[0]), p.c[(p.nRoundsF/2+1)*t+i])
+
+		newState0 := api.Mul(row[0], state[0])
+		for j := 1; j < t; j++ {
+			newState0 = api.Add(newState0, api.Mul(row[j], state[j]))
+		}
+
+		for k := 1; k < t; k++ {
+			state[k] = api.Add(state[k], api.Mul(state[0], row[t+k-1]))
+		}
+
+		state[0] = newState0
+	}
+
+	for i := 0; i < p.nRoundsF/2-1; i++ {
+		exp5State(api, state)
+		addRoundKeys(api, state, p.c, (p.nRoundsF/2+1)*t+p.nRoundsP+i*t)
+		state = mix(api, state, p.m)
+	}
+
+	exp5State(api, state)
+
+	return mix(api, state, p.m)
+}
+
+func addRoundKeys(api frontend.API, state []frontend.Variable, c []*big.Int, r int) {
+	for i := range state {
+		state[i] = api.Add(state[i], c[r+i])
+	}
+}
+
+func exp5(api frontend.API, x frontend.Variable) frontend.Variable {
+	x2 := api.Mul(x, x)
+	x4 := api.Mul(x2, x2)
+
+	return api.Mul(x4, x)
+}
+
+func exp5State(api frontend.API, state []frontend.Variable) {
+	for i := range state {
+		state[i] = exp5(api, state[i])
+	}
+}
+
+// mix returns newState[i] = sum_j m[j][i] * state[j].
+func mix(api frontend.API, state []frontend.Variable, m [][]*big.Int) []frontend.Variable {
+	out := make([]frontend.Variable, len(state))
+	for i := range out {
+		out[i] = api.Mul(m[0][i], state[0])
+		for j := 1; j < len(state); j++ {
+			out[i] = api.Add(out[i], api.Mul(m[j][i], state[j]))
+		}
+	}
+
+	return out
+}
+
+func ints(values ...string) []*big.Int {
+	out := make([]*big.Int, len(values))
+	for i, v := range values {
+		x, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			panic("poseidon: invalid constant " + v)
+		}
+		out[i] = x
+	}
+
+	return out
+}
+
+func matrix(rows ...[]*big.Int) [][]*big.Int {
+	return rows
+}
+
+var widths = map[int]*params{
+{{- range .Widths}}
+	{{.T}}: &t{{.T}},
+{{- end}}
+}
+{{range .Widths}}
+// t{{.T}} - constants of state width {{.T}} ({{.Inputs}} inputs).
+var t{{.T}} = params{
+	nRoundsF: {{.NRoundsF}},
+	nRoundsP: {{.NRoundsP}},
+	c: ints(
+{{list (quote .C) "\t\t"}},
+	),
+	s: ints(
+{{list (quote .S) "\t\t"}},
+	),
+	m: matrix(
+{{- range .M}}
+		ints({{inline (quote .)}}),
+{{- end}}
+	),
+	p: matrix(
+{{- range .P}}
+		ints({{inline (quote .)}}),
+{{- end}}
+	),
+}
+{{end}}`))
@@ -0,0 +1,311 @@
+//go:build !(js && wasm)
+
+package poseidon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// circomArray - функція вилучення значень масиву "var NAME[...] = [...]" з шаблону PoseidonT{t}
+func circomArray(t *testing.T, src, template, name string) []string {
+	start := strings.Index(src, "template "+template+"() {")
+	if start < 0 {
+		t.Fatalf("template %s is missing", template)
+	}
+	body := src[start:]
+	body = body[:strings.Index(body, "\n}\n")]
+
+	m := regexp.MustCompile(`var ` + name + `(?:\[\d+\])+ = \[([^;]*)\];`).FindStringSubmatch(body)
+	if m == nil {
+		t.Fatalf("%s: array %s is missing", template, name)
+	}
+
+	return regexp.MustCompile(`\d+`).FindAllString(m[1], -1)
+}
+
+func TestGenerateCircom(t *testing.T) {
+	var buf bytes.Buffer
+	if err := GenerateCircom(&buf, 5, 3, 3); err != nil {
+		t.Fatal(err)
+	}
+	src := buf.String()
+
+	if n := strings.Count(src, "template PoseidonT"); n != 2 {
+		t.Fatalf("generated %d width templates, expected 2", n)
+	}
+
+	for _, width := range []int{3, 5} {
+		template := fmt.Sprintf("PoseidonT%d", width)
+
+		flatten := func(m [][]*big.Int) []*big.Int {
+			var out []*big.Int
+			for _, row := range m {
+				out = append(out, row...)
+			}
+			return out
+		}
+
+		for name, want := range map[string][]*big.Int{
+			"C": c.c[width-2],
+			"S": c.s[width-2],
+			"M": flatten(c.m[width-2]),
+			"P": flatten(c.p[width-2]),
+		} {
+			got := circomArray(t, src, template, name)
+			if len(got) != len(want) {
+				t.Fatalf("%s: %s has %d values, expected %d", template, name, len(got), len(want))
+			}
+
+			for i := range want {
+				if got[i] != want[i].String() {
+					t.Fatalf("%s: %s[%d] is %s, expected %s", template, name, i, got[i], want[i])
+				}
+			}
+		}
+
+		rounds := fmt.Sprintf("PoseidonRounds(%d, %d, %d, C, S, M, P)", width, NROUNDSF, NROUNDSP[width-2])
+		if !strings.Contains(src, rounds) {
+			t.Fatalf("%s does not instantiate %s", template, rounds)
+		}
+	}
+
+	if err := GenerateCircom(&buf, 18); !errors.Is(err, ErrInputsLength) {
+		t.Fatalf("width 18 returned %v", err)
+	}
+}
+
+// TestGenerateCircomGolden - перевіряє, що шаблон PoseidonT3 збігається з testdata/poseidon_t3.circom (go generate).
+// Будь-яка зміна структури раундів шаблону видна в diff еталона; той самий шаблон компілятором circom перевіряє
+// TestCircomWitness, якщо circom доступний.
+func TestGenerateCircomGolden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := GenerateCircom(&buf, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	golden, err := os.ReadFile(filepath.Join("testdata", "poseidon_t3.circom"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), golden) {
+		t.Fatal("testdata/poseidon_t3.circom is out of date, run go generate")
+	}
+}
+
+// TestCircomWitness - компілює шаблон PoseidonT3 компілятором circom і перевіряє вихід у свідку, обчисленому
+// згенерованим WebAssembly (потрібні circom і node в PATH)
+func TestCircomWitness(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping circom compilation in short mode")
+	}
+
+	circom, err := exec.LookPath("circom")
+	if err != nil {
+		t.Skip("circom is not in PATH; only the golden template is checked (TestGenerateCircomGolden)")
+	}
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is not in PATH; it is required to compute circom witnesses")
+	}
+
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	if err := GenerateCircom(&buf, 3); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, dir, "poseidon.circom", buf.Bytes())
+	writeTestFile(t, dir, "main.circom", []byte("pragma circom 2.0.0;\n\ninclude \"poseidon.circom\";\n\ncomponent main = PoseidonT3();\n"))
+	writeTestFile(t, dir, "input.json", []byte(`{"inputs": ["1", "2"]}`))
+
+	for _, args := range [][]string{
+		{circom, "main.circom", "--wasm", "-o", "."},
+		{node, filepath.Join("main_js", "generate_witness.js"), filepath.Join("main_js", "main.wasm"), "input.json", "witness.wtns"},
+	} {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("%s: %v\n%s", filepath.Base(args[0]), err, out)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "witness.wtns"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	witness, prime, err := readWitness(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// свідок: константа 1, виходи main, входи main, проміжні сигнали
+	if prime.Cmp(q) != 0 || len(witness) < 4 {
+		t.Fatalf("witness over %s has %d values", prime, len(witness))
+	}
+
+	if want := Hash(ints(1, 2)); witness[1].Cmp(want) != 0 || witness[2].Int64() != 1 || witness[3].Int64() != 2 {
+		t.Fatalf("witness starts with %v, expected out %s", witness[:4], want)
+	}
+}
+
+// readWitness - функція розбору файла свідка circom (.wtns): секція 1 - розмір елемента, модуль поля і кількість
+// значень, секція 2 - значення (little-endian)
+func readWitness(data []byte) ([]*big.Int, *big.Int, error) {
+	if len(data) < 12 || string(data[:4]) != "wtns" {
+		return nil, nil, errors.New("not a wtns file")
+	}
+
+	le := func(b []byte) *big.Int {
+		be := make([]byte, len(b))
+		for i := range b {
+			be[len(b)-1-i] = b[i]
+		}
+		return new(big.Int).SetBytes(be)
+	}
+
+	var n8, count int
+	var prime *big.Int
+	var witness []*big.Int
+
+	sections := int(binary.LittleEndian.Uint32(data[8:]))
+	data = data[12:]
+
+	for i := 0; i < sections; i++ {
+		if len(data) < 12 {
+			return nil, nil, errors.New("truncated wtns section header")
+		}
+		typ, size := binary.LittleEndian.Uint32(data), binary.LittleEndian.Uint64(data[4:])
+		data = data[12:]
+		if uint64(len(data)) < size {
+			return nil, nil, errors.New("truncated wtns section")
+		}
+		body := data[:size]
+		data = data[size:]
+
+		switch typ {
+		case 1:
+			if len(body) < 4 {
+				return nil, nil, errors.New("truncated wtns header")
+			}
+			n8 = int(binary.LittleEndian.Uint32(body))
+			if len(body) < 8+n8 {
+				return nil, nil, errors.New("truncated wtns header")
+			}
+			prime = le(body[4 : 4+n8])
+			count = int(binary.LittleEndian.Uint32(body[4+n8:]))
+		case 2:
+			if n8 == 0 || len(body) != n8*count {
+				return nil, nil, errors.New("wtns values do not match the header")
+			}
+			for j := 0; j < count; j++ {
+				witness = append(witness, le(body[j*n8:(j+1)*n8]))
+			}
+		}
+	}
+
+	if prime == nil || witness == nil {
+		return nil, nil, errors.New("wtns file has no witness")
+	}
+
+	return witness, prime, nil
+}
+
+// TestGenerateGnarkUpToDate - перевіряє, що gnark/poseidon.go згенерований з поточних констант (go generate)
+func TestGenerateGnarkUpToDate(t *testing.T) {
+	var buf bytes.Buffer
+	if err := GenerateGnark(&buf, "poseidongnark", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	checked, err := os.ReadFile(filepath.Join("..", "gnark", "poseidon.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), checked) {
+		t.Fatal("gnark/poseidon.go is out of date, run go generate")
+	}
+}
+
+// gnarkVector - вектор для тесту гаджета в gnark/poseidon_test.go
+type gnarkVector struct {
+	Inputs []string `json:"inputs"`
+	Hash   string   `json:"hash"`
+}
+
+// TestGnarkGadget - генерує гаджет gnark для всіх ширин 2..17 у тимчасову копію модуля gnark і перевіряє тестовим
+// рушієм gnark (test.IsSolved), що гаджет обчислює Hash і відхиляє неправильний геш. Тест не звертається до мережі
+// (GOPROXY=off): якщо залежності модуля gnark відсутні в кеші модулів, тест пропускається.
+func TestGnarkGadget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping gnark build in short mode")
+	}
+
+	dir := t.TempDir()
+
+	for _, name := range []string{"go.mod", "go.sum", "poseidon_test.go"} {
+		data, err := os.ReadFile(filepath.Join("..", "gnark", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeTestFile(t, dir, name, data)
+	}
+
+	var gadget bytes.Buffer
+	if err := GenerateGnark(&gadget, "poseidongnark"); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, dir, "poseidon.go", gadget.Bytes())
+
+	var vectors []gnarkVector
+	for n := 1; n <= INPUTS; n++ {
+		input := make([]*big.Int, n)
+		v := gnarkVector{Inputs: make([]string, n)}
+
+		for i := range input {
+			input[i] = new(big.Int).Sub(q, big.NewInt(int64(i*n+1)))
+			v.Inputs[i] = input[i].String()
+		}
+
+		v.Hash = Hash(input).String()
+		vectors = append(vectors, v)
+	}
+
+	data, err := json.Marshal(vectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vectorsFile := writeTestFile(t, dir, "vectors.json", data)
+
+	goTool := filepath.Join(runtime.GOROOT(), "bin", "go")
+	env := append(os.Environ(), "GOPROXY=off", "GOFLAGS=-mod=mod")
+
+	list := exec.Command(goTool, "list", "-deps", "-test", ".")
+	list.Dir = dir
+	list.Env = env
+	if out, err := list.CombinedOutput(); err != nil {
+		t.Skipf("gnark module dependencies are not available offline: %v\n%s", err, out)
+	}
+
+	cmd := exec.Command(goTool, "test", ".")
+	cmd.Dir = dir
+	cmd.Env = append(env, "POSEIDON_GNARK_VECTORS="+vectorsFile)
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("gnark gadget test failed: %v\n%s", err, out)
+	}
+}
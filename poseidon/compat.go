@@ -0,0 +1,222 @@
+package poseidon
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// Variant - профіль сумісності Poseidon (поле, константи, раунди, розміщення входів і доповнення губки),
+// яким гешує HashWithOptions
+type Variant int
+
+// Профілі arkworks (hashArkworks) і Halo2 P128Pow5T3 (hashHalo2) реалізовані, але не експортуються, доки їхні геші
+// не звірені з векторами самих бібліотек (TestArkworksUpstreamVectors, TestHalo2UpstreamVectors)
+const (
+	// VariantCircom - параметри Hash: поле BN254, константи circomlib, стан [0, input...], результат state[0]
+	VariantCircom Variant = iota
+	// VariantNeptune - параметри Neptune (Filecoin): поле BLS12-381 Fr, константи з Grain LFSR, матриця Коші,
+	// стан [2^arity - 1, input...] (тег домену дерева Меркла), результат state[1]. Арності 2, 4, 8 і 11.
+	VariantNeptune
+)
+
+// variantNames - назви профілів для String і ParseVariant
+var variantNames = []string{"circom", "neptune"}
+
+// String - функція назви профілю ("circom", "neptune")
+func (v Variant) String() string {
+	if v < 0 || int(v) >= len(variantNames) {
+		return fmt.Sprintf("Variant(%d)", int(v))
+	}
+
+	return variantNames[v]
+}
+
+// ParseVariant - функція пошуку профілю за назвою; повертає ErrInvalidVariant для невідомої назви
+func ParseVariant(name string) (Variant, error) {
+	for i, n := range variantNames {
+		if n == name {
+			return Variant(i), nil
+		}
+	}
+
+	return 0, ErrInvalidVariant
+}
+
+var ErrInvalidVariant = errors.New("poseidon: unsupported hash variant or strength")
+
+// HashOptions - параметри HashWithOptions; нульове значення відповідає Hash
+type HashOptions struct {
+	Variant  Variant
+	Strength Strength // лише для VariantNeptune
+}
+
+// pallasP - порядок поля Pallas Fp (поле P128Pow5T3 в Halo2)
+var pallasP, _ = new(big.Int).SetString("40000000000000000000000000000000224698fc094cf91b992d30ed00000001", 16)
+
+// arkworksParams - параметри arkworks за замовчуванням (PARAMS_OPT_FOR_CONSTRAINTS) для швидкості 2..8:
+// степінь S-блоку, повні й часткові раунди
+var arkworksParams = [][3]int{{17, 8, 31}, {11, 8, 33}, {11, 8, 33}, {7, 8, 49}, {7, 8, 49}, {7, 8, 49}, {7, 8, 49}}
+
+var (
+	arkworksOnce   [7]sync.Once
+	arkworksTables [7]fieldPermutation // за індексом швидкість-2
+
+	halo2Once  sync.Once
+	halo2Table fieldPermutation
+)
+
+// arkworksConstants - функція параметрів arkworks для швидкості rate (find_poseidon_ark_and_mds з skip_matrices = 0):
+// константи раундів - Grain LFSR з відкиданням, матриця Коші з 2t наступних елементів за модулем r
+func arkworksConstants(rate int) *fieldPermutation {
+	arkworksOnce[rate-2].Do(func() {
+		t, params := rate+1, arkworksParams[rate-2]
+		fp := &arkworksTables[rate-2]
+		fp.p, fp.alpha, fp.nRoundsF, fp.nRoundsP = blsR, big.NewInt(int64(params[0])), params[1], params[2]
+
+		g := newGrain(0, 255, t, fp.nRoundsF, fp.nRoundsP)
+		fp.c = make([]*big.Int, (fp.nRoundsF+fp.nRoundsP)*t)
+		for i := range fp.c {
+			fp.c[i] = g.field(blsR, 255)
+		}
+
+		xs, ys := make([]*big.Int, t), make([]*big.Int, t)
+		for i := range xs {
+			xs[i] = g.fieldModP(blsR, 255)
+		}
+		for i := range ys {
+			ys[i] = g.fieldModP(blsR, 255)
+		}
+		fp.m = cauchyMatrix(xs, ys, blsR)
+	})
+
+	return &arkworksTables[rate-2]
+}
+
+// halo2Constants - функція параметрів P128Pow5T3 (generate_constants у halo2_gadgets): 8 повних і 56 часткових
+// раундів, x^5, константи раундів - Grain LFSR з відкиданням, матриця Коші з перших 2t різних елементів за модулем p
+func halo2Constants() *fieldPermutation {
+	halo2Once.Do(func() {
+		const t = 3
+		fp := &halo2Table
+		fp.p, fp.alpha, fp.nRoundsF, fp.nRoundsP = pallasP, big5int, 8, 56
+
+		g := newGrain(0, 255, t, fp.nRoundsF, fp.nRoundsP)
+		fp.c = make([]*big.Int, (fp.nRoundsF+fp.nRoundsP)*t)
+		for i := range fp.c {
+			fp.c[i] = g.field(pallasP, 255)
+		}
+
+		for fp.m == nil {
+			vals := make([]*big.Int, 2*t)
+			unique := true
+
+			for i := range vals {
+				vals[i] = g.fieldModP(pallasP, 255)
+				for _, v := range vals[:i] {
+					unique = unique && v.Cmp(vals[i]) != 0
+				}
+			}
+
+			if unique {
+				fp.m = cauchyMatrix(vals[:t], vals[t:], pallasP)
+			}
+		}
+	})
+
+	return &halo2Table
+}
+
+// fieldElements - функція перевірки і копіювання елементів поля за модулем p
+func fieldElements(input []*big.Int, p *big.Int) ([]*big.Int, error) {
+	out := make([]*big.Int, len(input))
+	for i, x := range input {
+		if x == nil || x.Sign() < 0 || x.Cmp(p) >= 0 {
+			return nil, ErrInvalidInput
+		}
+		out[i] = new(big.Int).Set(x)
+	}
+
+	return out, nil
+}
+
+// spongeHash - функція гешування губкою з ємністю на позиції capacity і швидкістю rate: блоки входу (останній
+// доповнюється нулями) додаються до state[offset..offset+rate), після кожного блоку виконується перестановка
+// (для порожнього входу - одна перестановка), результат - state[offset]
+func spongeHash(fp *fieldPermutation, state []*big.Int, offset, rate int, input []*big.Int) *big.Int {
+	for start := 0; start < len(input) || start == 0; start += rate {
+		for i := 0; i < rate && start+i < len(input); i++ {
+			x := state[offset+i]
+			x.Add(x, input[start+i]).Mod(x, fp.p)
+		}
+
+		state = fp.permute(state)
+	}
+
+	return state[offset]
+}
+
+// hashArkworks - функція гешування PoseidonSponge з arkworks (ark-crypto-primitives) над BLS12-381 Fr з параметрами
+// find_poseidon_ark_and_mds для швидкості rate (2..8, 0 - 2) і ємності 1: absorb(input), squeeze_field_elements(1).
+// Стан [0 (ємність), 0...], вхід поглинається в state[1..rate], squeeze після поглинання виконує перестановку
+// і повертає state[1]. З arkworks звірені лише параметри (TestCompatConstants), геші - ні.
+func hashArkworks(input []*big.Int, rate int) (*big.Int, error) {
+	if rate == 0 {
+		rate = 2
+	}
+	if rate < 2 || rate > len(arkworksParams)+1 {
+		return nil, ErrInvalidVariant
+	}
+
+	elems, err := fieldElements(input, blsR)
+	if err != nil {
+		return nil, err
+	}
+
+	state := make([]*big.Int, rate+1)
+	for i := range state {
+		state[i] = new(big.Int)
+	}
+
+	return spongeHash(arkworksConstants(rate), state, 1, rate, elems), nil
+}
+
+// hashHalo2 - функція гешування Hash<P128Pow5T3, ConstantLength<L>> з halo2_gadgets (Zcash) над полем Pallas Fp:
+// ширина 3, швидкість 2, стан [0, 0, L * 2^64] з L = len(input), вхід доповнюється нулями до кратного 2 і поглинається
+// в state[0..2), результат state[0]. З halo2_gadgets звірені лише константи (TestCompatConstants), перестановка
+// і геші - ні.
+func hashHalo2(input []*big.Int) (*big.Int, error) {
+	elems, err := fieldElements(input, pallasP)
+	if err != nil {
+		return nil, err
+	}
+
+	state := []*big.Int{new(big.Int), new(big.Int), new(big.Int).Lsh(big.NewInt(int64(len(input))), 64)}
+
+	return spongeHash(halo2Constants(), state, 0, 2, elems), nil
+}
+
+// HashWithOptions - функція гешування вхідного масиву з вибором профілю сумісності:
+//   - VariantCircom: те саме, що Hash (1..16 елементів поля BN254);
+//   - VariantNeptune: геш вузла дерева Меркла Neptune (HashType::MerkleTree) над BLS12-381 Fr для 2, 4, 8 або 11
+//     елементів зі стійкістю opts.Strength, сумісний з neptune::Poseidon::hash і деревами Filecoin.
+//
+// Вхідний масив не змінюється. Повертає ErrInputsLength для неправильної кількості елементів, ErrInvalidInput для
+// елемента поза полем профілю і ErrInvalidVariant для невідомого профілю чи стійкості.
+func HashWithOptions(input []*big.Int, opts HashOptions) (*big.Int, error) {
+	switch {
+	case opts.Variant == VariantCircom && opts.Strength == Standard:
+		state, err := copyState(append([]*big.Int{big.NewInt(0)}, input...))
+		if err != nil {
+			return nil, err
+		}
+
+		return permute(state)[0], nil
+
+	case opts.Variant == VariantNeptune && (opts.Strength == Standard || opts.Strength == Strengthened):
+		return hashNeptune(input, opts.Strength)
+	}
+
+	return nil, ErrInvalidVariant
+}
@@ -0,0 +1,221 @@
+package poseidon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+// compatVector - вектор профілю сумісності з testdata/compat_vectors.json (числа - десяткові рядки)
+type compatVector struct {
+	Profile  string   `json:"profile"`
+	Strength string   `json:"strength,omitempty"` // "standard" або "strengthened" для neptune
+	Rate     int      `json:"rate,omitempty"`     // швидкість для arkworks
+	Inputs   []string `json:"inputs"`
+	Hash     string   `json:"hash"`
+}
+
+// compatHash - функція гешування вектора його профілем: експортовані профілі - через HashWithOptions, arkworks і halo2 -
+// внутрішніми hashArkworks і hashHalo2
+func compatHash(v compatVector, input []*big.Int) (*big.Int, error) {
+	switch v.Profile {
+	case "arkworks":
+		return hashArkworks(input, v.Rate)
+	case "halo2-p128pow5t3":
+		return hashHalo2(input)
+	}
+
+	variant, err := ParseVariant(v.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := HashOptions{Variant: variant}
+	if v.Strength == "strengthened" {
+		opts.Strength = Strengthened
+	}
+
+	return HashWithOptions(input, opts)
+}
+
+// TestCompatVectors - перевіряє HashWithOptions на векторах усіх профілів. Вектори circom порівнюються ще й з
+// go-iden3-crypto; вектори neptune для входу 0..arity-1 - ті самі, що в TestHashNeptune; вектори неекспортованих
+// профілів arkworks і halo2 обчислено цією реалізацією (регресійні, не сумісність: її перевіряють
+// TestArkworksUpstreamVectors і TestHalo2UpstreamVectors), параметри яких перевіряє TestCompatConstants
+func TestCompatVectors(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "compat_vectors.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vectors []compatVector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatal(err)
+	}
+
+	seen := map[string]bool{}
+
+	for i, v := range vectors {
+		seen[v.Profile] = true
+
+		input := make([]*big.Int, len(v.Inputs))
+		for j, s := range v.Inputs {
+			input[j], _ = new(big.Int).SetString(s, 10)
+		}
+
+		got, err := compatHash(v, input)
+		if err != nil {
+			t.Fatalf("vector %d (%s): %v", i, v.Profile, err)
+		}
+
+		if got.String() != v.Hash {
+			t.Fatalf("vector %d (%s, %d inputs): hash is %s, expected %s", i, v.Profile, len(input), got, v.Hash)
+		}
+
+		if v.Profile == VariantCircom.String() {
+			if want, err := poseidon.Hash(input); err != nil || want.Cmp(got) != 0 {
+				t.Fatalf("vector %d: iden3 hash is %v, %v", i, want, err)
+			}
+		}
+	}
+
+	for _, name := range append(variantNames, "arkworks", "halo2-p128pow5t3") {
+		if !seen[name] {
+			t.Fatalf("no vectors for profile %s", name)
+		}
+	}
+}
+
+// TestCompatConstants - порівнює згенеровані параметри з опублікованими: ark[0][0] і mds[0][0] з тесту
+// bls12_381_fr_poseidon_default_parameters_test в arkworks (швидкість 2) і ROUND_CONSTANTS[0][0], MDS[0][0]
+// з halo2_gadgets/src/poseidon/primitives/fp.rs (ліми little-endian)
+func TestCompatConstants(t *testing.T) {
+	ark := arkworksConstants(2)
+	if ark.nRoundsF != 8 || ark.nRoundsP != 31 || ark.alpha.Int64() != 17 || len(ark.c) != 39*3 {
+		t.Fatalf("arkworks rate 2 parameters: alpha %s, %d full and %d partial rounds, %d constants",
+			ark.alpha, ark.nRoundsF, ark.nRoundsP, len(ark.c))
+	}
+
+	if got := ark.c[0].String(); got != "27117311055620256798560880810000042840428971800021819916023577129547249660720" {
+		t.Fatalf("arkworks ark[0][0] is %s", got)
+	}
+
+	if got := ark.m[0][0].String(); got != "26017457457808754696901916760153646963713419596921330311675236858336250747575" {
+		t.Fatalf("arkworks mds[0][0] is %s", got)
+	}
+
+	limbs := func(x *big.Int) string {
+		b := x.FillBytes(make([]byte, 32))
+		return fmt.Sprintf("[%x, %x, %x, %x]", b[24:], b[16:24], b[8:16], b[:8])
+	}
+
+	halo2 := halo2Constants()
+	if len(halo2.c) != 64*3 || len(halo2.m) != 3 {
+		t.Fatalf("halo2: %d round constants, %dx%d matrix", len(halo2.c), len(halo2.m), len(halo2.m))
+	}
+
+	if got := limbs(halo2.c[0]); got != "[57538c2596426303, 4e71162f31003b70, 353f628f76d110f3, 360d7470611e473d]" {
+		t.Fatalf("halo2 ROUND_CONSTANTS[0][0] is %s", got)
+	}
+
+	if got := limbs(halo2.m[0][0]); got != "[323f2486d7e11b63, 97d7a0ab23850b56, b3d59fbdc8c9ead4, 0ab5e5b874a68de7]" {
+		t.Fatalf("halo2 MDS[0][0] is %s", got)
+	}
+}
+
+func TestHashWithOptions(t *testing.T) {
+	got, err := HashWithOptions(ints(1, 2), HashOptions{})
+	if err != nil || got.Cmp(Hash(ints(1, 2))) != 0 {
+		t.Fatalf("circom variant returned %v, %v", got, err)
+	}
+
+	for i, name := range variantNames {
+		if v, err := ParseVariant(name); err != nil || v != Variant(i) || v.String() != name {
+			t.Fatalf("profile %q parsed as %v, %v", name, v, err)
+		}
+	}
+
+	if _, err := ParseVariant("poseidon2"); !errors.Is(err, ErrInvalidVariant) {
+		t.Fatalf("unknown profile name returned %v", err)
+	}
+
+	// швидкість arkworks: вхід довжини rate поглинається однією перестановкою, rate+1 - двома
+	for rate := 2; rate <= 8; rate++ {
+		input := make([]*big.Int, rate+1)
+		for i := range input {
+			input[i] = big.NewInt(int64(i + 1))
+		}
+
+		fp := arkworksConstants(rate)
+		state := make([]*big.Int, rate+1)
+		state[0] = new(big.Int)
+		for i := 0; i < rate; i++ {
+			state[i+1] = new(big.Int).Set(input[i])
+		}
+		state = fp.permute(state)
+		state[1].Add(state[1], input[rate])
+		want := fp.permute(state)[1]
+
+		if got, err := hashArkworks(input, rate); err != nil || got.Cmp(want) != 0 {
+			t.Fatalf("arkworks rate %d returned %v, %v; expected %s", rate, got, err, want)
+		}
+	}
+
+	// ємність Halo2 кодує довжину, тому доповнення нулями не дає колізій
+	one, _ := hashHalo2(ints(0))
+	two, _ := hashHalo2(ints(0, 0))
+	if one.Cmp(two) == 0 {
+		t.Fatal("halo2: hashes of (0) and (0, 0) collide")
+	}
+
+	input := []*big.Int{new(big.Int).Sub(pallasP, big.NewInt(1)), big.NewInt(7)}
+	if _, err := hashHalo2(input); err != nil || input[0].Cmp(pallasP) >= 0 || input[1].Int64() != 7 {
+		t.Fatalf("halo2: element p-1 returned %v or input was modified", err)
+	}
+
+	for _, tc := range []struct {
+		opts  HashOptions
+		input []*big.Int
+		err   error
+	}{
+		{HashOptions{Strength: Strengthened}, ints(1, 2), ErrInvalidVariant},
+		{HashOptions{Variant: VariantNeptune, Strength: 2}, ints(1, 2), ErrInvalidVariant},
+		{HashOptions{Variant: -1}, ints(1, 2), ErrInvalidVariant},
+		{HashOptions{Variant: VariantNeptune + 1}, ints(1, 2), ErrInvalidVariant}, // профіль arkworks не експортується
+		{HashOptions{Variant: VariantNeptune + 2}, ints(1, 2), ErrInvalidVariant}, // профіль halo2 не експортується
+		{HashOptions{}, []*big.Int{q}, ErrInvalidInput},
+		{HashOptions{}, nil, ErrInputsLength},
+	} {
+		if _, err := HashWithOptions(tc.input, tc.opts); !errors.Is(err, tc.err) {
+			t.Fatalf("options %+v returned %v, expected %v", tc.opts, err, tc.err)
+		}
+	}
+
+	for _, name := range []string{"arkworks", "halo2-p128pow5t3"} {
+		if _, err := ParseVariant(name); !errors.Is(err, ErrInvalidVariant) {
+			t.Fatalf("unexported profile %q parsed with %v", name, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		hash func() (*big.Int, error)
+		err  error
+	}{
+		{"arkworks rate 1", func() (*big.Int, error) { return hashArkworks(ints(1, 2), 1) }, ErrInvalidVariant},
+		{"arkworks rate 9", func() (*big.Int, error) { return hashArkworks(ints(1, 2), 9) }, ErrInvalidVariant},
+		{"arkworks element r", func() (*big.Int, error) { return hashArkworks([]*big.Int{blsR}, 2) }, ErrInvalidInput},
+		{"halo2 element p", func() (*big.Int, error) { return hashHalo2([]*big.Int{pallasP}) }, ErrInvalidInput},
+		{"halo2 nil element", func() (*big.Int, error) { return hashHalo2([]*big.Int{nil}) }, ErrInvalidInput},
+	} {
+		if _, err := tc.hash(); !errors.Is(err, tc.err) {
+			t.Fatalf("%s returned %v, expected %v", tc.name, err, tc.err)
+		}
+	}
+}
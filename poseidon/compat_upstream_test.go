@@ -0,0 +1,159 @@
+//go:build upstream
+
+// Неекспортовані профілі arkworks і halo2 перевіряються на векторах самих бібліотек лише з тегом upstream
+// (go test -tags upstream): файли векторів копіюються або генеруються в testdata/upstream (див. README).
+
+package poseidon
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// upstreamFile - функція читання файла з testdata/upstream; якщо файла немає, тест падає з інструкцією how
+func upstreamFile(t *testing.T, name, how string) []byte {
+	t.Helper()
+
+	path := filepath.Join("testdata", "upstream", name)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("%s is missing; %s", path, how)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+// rustLimbs - функція розбору елементів поля з літералів Rust: кожні 4 числа 0x... - ліми little-endian
+func rustLimbs(t *testing.T, src string) []*big.Int {
+	hex := regexp.MustCompile(`0x[0-9a-fA-F_]+`).FindAllString(src, -1)
+	if len(hex)%4 != 0 {
+		t.Fatalf("%d limbs do not form field elements", len(hex))
+	}
+
+	out := make([]*big.Int, len(hex)/4)
+	for i := range out {
+		out[i] = new(big.Int)
+		for j := 3; j >= 0; j-- {
+			limb, ok := new(big.Int).SetString(strings.ReplaceAll(hex[4*i+j][2:], "_", ""), 16)
+			if !ok || limb.BitLen() > 64 {
+				t.Fatalf("invalid limb %s", hex[4*i+j])
+			}
+			out[i].Lsh(out[i], 64).Or(out[i], limb)
+		}
+	}
+
+	return out
+}
+
+// TestHalo2UpstreamVectors - перевіряє перестановку і Hash<P128Pow5T3, ConstantLength<2>> профілю halo2 на векторах
+// модуля fp з halo2_gadgets/src/poseidon/primitives/test_vectors.rs (PermuteTestVector: initial_state і final_state,
+// HashTestVector: input і output; елементи Pallas Fp у лімах для from_raw). Файл копіюється з halo2 без змін.
+func TestHalo2UpstreamVectors(t *testing.T) {
+	data := upstreamFile(t, "halo2_test_vectors.rs",
+		"copy halo2_gadgets/src/poseidon/primitives/test_vectors.rs from zcash/halo2 there")
+	src := string(data)
+
+	start := strings.Index(src, "mod fp")
+	if start < 0 {
+		t.Fatal("test_vectors.rs has no fp module")
+	}
+	src = src[start:]
+	if end := strings.Index(src, "mod fq"); end >= 0 {
+		src = src[:end]
+	}
+
+	// тіло функції name: від її оголошення до наступної функції модуля
+	body := func(name string) string {
+		i := strings.Index(src, "fn "+name+"()")
+		if i < 0 {
+			t.Fatalf("fp module has no %s()", name)
+		}
+		s := src[i+len(name)+5:]
+		if j := strings.Index(s, "fn "); j >= 0 {
+			s = s[:j]
+		}
+		return s
+	}
+
+	fp := halo2Constants()
+
+	permute := rustLimbs(t, body("permute"))
+	if len(permute) == 0 || len(permute)%6 != 0 {
+		t.Fatalf("%d permutation test vector elements", len(permute))
+	}
+
+	for i := 0; i < len(permute); i += 6 {
+		state := cloneInts(permute[i : i+3])
+		state = fp.permute(state)
+
+		for j, want := range permute[i+3 : i+6] {
+			if state[j].Cmp(want) != 0 {
+				t.Fatalf("permutation vector %d: state[%d] is %s, halo2 returned %s", i/6, j, state[j], want)
+			}
+		}
+	}
+
+	hash := rustLimbs(t, body("hash"))
+	if len(hash) == 0 || len(hash)%3 != 0 {
+		t.Fatalf("%d hash test vector elements", len(hash))
+	}
+
+	for i := 0; i < len(hash); i += 3 {
+		got, err := hashHalo2(hash[i : i+2])
+		if err != nil {
+			t.Fatalf("hash vector %d: %v", i/3, err)
+		}
+
+		if got.Cmp(hash[i+2]) != 0 {
+			t.Fatalf("hash vector %d: hash is %s, halo2 returned %s", i/3, got, hash[i+2])
+		}
+	}
+}
+
+// TestArkworksUpstreamVectors - перевіряє профіль arkworks на гешах PoseidonSponge, обчислених самим arkworks
+// програмою testdata/upstream/arkworks-sponge (формат testdata/compat_vectors.json)
+func TestArkworksUpstreamVectors(t *testing.T) {
+	data := upstreamFile(t, "arkworks-sponge.json",
+		"generate it with cargo run --manifest-path testdata/upstream/arkworks-sponge/Cargo.toml")
+
+	var file struct {
+		Source  string         `json:"source"`
+		Vectors []compatVector `json:"vectors"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(file.Vectors) == 0 {
+		t.Fatal("arkworks-sponge.json has no vectors")
+	}
+
+	for i, v := range file.Vectors {
+		if v.Profile != "arkworks" {
+			t.Fatalf("vector %d has profile %q", i, v.Profile)
+		}
+
+		input := make([]*big.Int, len(v.Inputs))
+		for j, s := range v.Inputs {
+			input[j], _ = new(big.Int).SetString(s, 10)
+		}
+
+		got, err := hashArkworks(input, v.Rate)
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+
+		if got.String() != v.Hash {
+			t.Fatalf("vector %d (rate %d, %d inputs): hash is %s, %s returned %s", i, v.Rate, len(input), got, file.Source, v.Hash)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package poseidon
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrCostOverflow = errors.New("poseidon: merkle proof cost overflows int")
+
+// CircuitCost - оцінка вартості перестановки Poseidon ширини Width у різних системах доведення.
+// Модель обчислення:
+//   - R1CS: S-блок x^5 - 3 обмеження (x^2, x^4, x^5), додавання констант і множення на матриці лінійні й безкоштовні;
+//   - PLONK (ворота з двома входами q_L*a + q_R*b + q_O*c + q_M*a*b + q_C = 0): S-блок - 3 ворота множення (константа
+//     раунду додається в перших воротах), лінійна комбінація k змінних - k-1 воріт (константи - через q_C), тому повний
+//     mix - t*(t-1) воріт, а розріджений mix часткового раунду - 2*(t-1) воріт;
+//   - PLONK з власними воротами S-блоку (x^5 в одних воротах, як у Halo2/Plonky2): S-блок - 1 ворота, лінійний шар той самий;
+//   - AIR: рядок трасування на стан після кожного раунду (і початковий), стовпчик на елемент стану, степінь обмежень 5.
+type CircuitCost struct {
+	Width    int // ширина стану t (кількість входів + 1)
+	NRoundsF int
+	NRoundsP int
+
+	SBoxes           int // кількість S-блоків: NRoundsF*t + NRoundsP
+	R1CS             int // обмеження R1CS
+	PlonkGates       int // ворота PLONK без власних воріт
+	PlonkCustomGates int // ворота PLONK з власними воротами S-блоку
+	AIRColumns       int
+	AIRRows          int
+	AIRCells         int // AIRColumns * AIRRows
+}
+
+// EstimateCost - функція оцінки вартості перестановки ширини 2..17 з NROUNDSF повними і NROUNDSP[width-2] частковими
+// раундами (див. модель в CircuitCost); повертає ErrInputsLength для ширини поза 2..17
+func EstimateCost(width int) (CircuitCost, error) {
+	if width < 2 || width > INPUTS+1 {
+		return CircuitCost{}, ErrInputsLength
+	}
+
+	cost := CircuitCost{Width: width, NRoundsF: NROUNDSF, NRoundsP: NROUNDSP[width-2]}
+
+	cost.SBoxes = cost.NRoundsF*width + cost.NRoundsP
+	cost.R1CS = 3 * cost.SBoxes
+
+	linear := cost.NRoundsF*width*(width-1) + cost.NRoundsP*2*(width-1) // NROUNDSF повних mix (M і P) і розріджені mix
+	cost.PlonkGates = 3*cost.SBoxes + linear
+	cost.PlonkCustomGates = cost.SBoxes + linear
+
+	cost.AIRColumns = width
+	cost.AIRRows = cost.NRoundsF + cost.NRoundsP + 1
+	cost.AIRCells = cost.AIRColumns * cost.AIRRows
+
+	return cost, nil
+}
+
+// CheapestMerkleArity - функція вибору арності 2..16 дерева Меркла з leaves листками, для якої доведення належності
+// (depth перестановок ширини arity+1 на шляху від листка до кореня) найдешевше за метрикою metric
+// (наприклад, func(c CircuitCost) int { return c.R1CS }). Вибір позиції вузла серед дітей і геш листа (одна перестановка
+// ширини 2, однакова для всіх арностей) не враховуються.
+// Повертає арність і вартість шляху; для leaves < 1 повертає ErrNoLeaves, а якщо вартість шляху для якоїсь арності
+// не вміщується в int - ErrCostOverflow.
+func CheapestMerkleArity(leaves int, metric func(CircuitCost) int) (int, int, error) {
+	if leaves < 1 {
+		return 0, 0, ErrNoLeaves
+	}
+
+	bestArity, bestCost := 0, 0
+
+	for arity := 2; arity <= INPUTS; arity++ {
+		cost, _ := EstimateCost(arity + 1)
+
+		depth, m := merkleDepth(arity, leaves), metric(cost)
+		if m > math.MaxInt/depth || m < math.MinInt/depth {
+			return 0, 0, ErrCostOverflow
+		}
+
+		if total := depth * m; bestArity == 0 || total < bestCost {
+			bestArity, bestCost = arity, total
+		}
+	}
+
+	return bestArity, bestCost, nil
+}
@@ -0,0 +1,129 @@
+package poseidon
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestEstimateCost(t *testing.T) {
+	cost, err := EstimateCost(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Poseidon(2) з circomlib: 8*3 + 57 = 81 S-блоків, 243 нелінійних обмеження
+	want := CircuitCost{
+		Width: 3, NRoundsF: 8, NRoundsP: 57,
+		SBoxes: 81, R1CS: 243,
+		PlonkGates: 243 + 8*3*2 + 57*2*2, PlonkCustomGates: 81 + 8*3*2 + 57*2*2,
+		AIRColumns: 3, AIRRows: 66, AIRCells: 198,
+	}
+	if cost != want {
+		t.Fatalf("width 3: cost is %+v, expected %+v", cost, want)
+	}
+
+	for width := 2; width <= INPUTS+1; width++ {
+		cost, err := EstimateCost(width)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// кількість S-блоків і повних mix перевіряється за трасуванням перестановки
+		input := make([]*big.Int, width-1)
+		for i := range input {
+			input[i] = big.NewInt(int64(i))
+		}
+
+		tr, err := HashTrace(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sboxes, fullMixes := 0, 0
+		for _, step := range tr.Steps {
+			switch {
+			case step.Op == "sbox" && step.Partial:
+				sboxes++
+			case step.Op == "sbox":
+				sboxes += width
+			case step.Op == "mix" && !step.Partial:
+				fullMixes++
+			}
+		}
+
+		if cost.SBoxes != sboxes || cost.R1CS != 3*sboxes || fullMixes != NROUNDSF {
+			t.Fatalf("width %d: %d S-boxes and %d R1CS constraints estimated, trace has %d S-boxes and %d full mixes",
+				width, cost.SBoxes, cost.R1CS, sboxes, fullMixes)
+		}
+
+		if cost.PlonkGates-cost.PlonkCustomGates != 2*sboxes || cost.AIRCells != width*(NROUNDSF+NROUNDSP[width-2]+1) {
+			t.Fatalf("width %d: inconsistent cost %+v", width, cost)
+		}
+	}
+
+	for _, width := range []int{1, INPUTS + 2} {
+		if _, err := EstimateCost(width); !errors.Is(err, ErrInputsLength) {
+			t.Fatalf("width %d returned %v", width, err)
+		}
+	}
+}
+
+func TestCheapestMerkleArity(t *testing.T) {
+	for _, leaves := range []int{1, 2, 5, 16, 17, 100} {
+		for arity := 2; arity <= INPUTS; arity++ {
+			proof, err := MerkleProof(testLeaves(leaves), arity, leaves-1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if depth := merkleDepth(arity, leaves); depth != len(proof) {
+				t.Fatalf("%d leaves, arity %d: depth %d, proof has %d steps", leaves, arity, depth, len(proof))
+			}
+		}
+	}
+
+	r1cs := func(c CircuitCost) int { return c.R1CS }
+
+	for _, leaves := range []int{2, 1000, 1 << 20, 1 << 30} {
+		arity, total, err := CheapestMerkleArity(leaves, r1cs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for other := 2; other <= INPUTS; other++ {
+			cost, _ := EstimateCost(other + 1)
+			if merkleDepth(other, leaves)*cost.R1CS < total {
+				t.Fatalf("%d leaves: arity %d (%d constraints) is cheaper than the chosen arity %d (%d)",
+					leaves, other, merkleDepth(other, leaves)*cost.R1CS, arity, total)
+			}
+		}
+
+		cost, _ := EstimateCost(arity + 1)
+		if total != merkleDepth(arity, leaves)*cost.R1CS {
+			t.Fatalf("%d leaves: arity %d reported %d constraints", leaves, arity, total)
+		}
+	}
+
+	if _, _, err := CheapestMerkleArity(0, r1cs); !errors.Is(err, ErrNoLeaves) {
+		t.Fatalf("0 leaves returned %v", err)
+	}
+
+	arity, total, err := CheapestMerkleArity(math.MaxInt, r1cs)
+	if err != nil {
+		t.Fatalf("MaxInt leaves returned %v", err)
+	}
+	if cost, _ := EstimateCost(arity + 1); total != merkleDepth(arity, math.MaxInt)*cost.R1CS {
+		t.Fatalf("MaxInt leaves: arity %d reported %d constraints", arity, total)
+	}
+
+	for _, metric := range []func(CircuitCost) int{
+		func(CircuitCost) int { return math.MaxInt / 2 },
+		func(CircuitCost) int { return math.MinInt / 2 },
+	} {
+		if _, _, err := CheapestMerkleArity(1000, metric); !errors.Is(err, ErrCostOverflow) {
+			t.Fatalf("overflowing metric returned %v", err)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package poseidon
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// fe - елемент поля в формі Монтгомері (x*R mod q, R = 2^256): чотири 64-бітні ліми, молодший перший.
+// Усі операції над fe виконують однакову послідовність інструкцій незалежно від значень операндів:
+// без розгалужень і ранніх виходів, умовне віднімання q робиться маскою.
+type fe [4]uint64
+
+var (
+	qLimbs fe     // модуль q
+	qInv   uint64 // -q^(-1) mod 2^64
+	feR2   fe     // R^2 mod q для переведення у форму Монтгомері
+	feOne  = fe{1}
+)
+
+func init() {
+	qLimbs = feFromWords(q)
+
+	inv := uint64(1) // q^(-1) mod 2^64 методом Ньютона: кожна ітерація подвоює кількість правильних бітів
+	for i := 0; i < 6; i++ {
+		inv *= 2 - qLimbs[0]*inv
+	}
+	qInv = -inv
+
+	r2 := new(big.Int).Lsh(big.NewInt(1), 512)
+	feR2 = feFromWords(r2.Mod(r2, q))
+}
+
+// feFromWords - функція перетворення невід'ємного x < 2^256 в ліми (без переведення у форму Монтгомері);
+// використовується лише для публічних констант
+func feFromWords(x *big.Int) fe {
+	var b [32]byte
+	x.FillBytes(b[:])
+
+	return feFromBytes(&b)
+}
+
+// feFromBytes - функція розбору 32-байтового big-endian масиву в ліми
+func feFromBytes(b *[32]byte) fe {
+	return fe{
+		binary.BigEndian.Uint64(b[24:32]),
+		binary.BigEndian.Uint64(b[16:24]),
+		binary.BigEndian.Uint64(b[8:16]),
+		binary.BigEndian.Uint64(b[0:8]),
+	}
+}
+
+// feLessThanQ - функція порівняння x < q без розгалужень; повертає 1 або 0
+func feLessThanQ(x *fe) uint64 {
+	var b uint64
+	_, b = bits.Sub64(x[0], qLimbs[0], 0)
+	_, b = bits.Sub64(x[1], qLimbs[1], b)
+	_, b = bits.Sub64(x[2], qLimbs[2], b)
+	_, b = bits.Sub64(x[3], qLimbs[3], b)
+
+	return b
+}
+
+// feReduce - функція умовного віднімання q: z = x - q, якщо x >= q, інакше z = x (x < 2q)
+func feReduce(z *fe, x *fe, hi uint64) {
+	var u fe
+	var b uint64
+
+	u[0], b = bits.Sub64(x[0], qLimbs[0], 0)
+	u[1], b = bits.Sub64(x[1], qLimbs[1], b)
+	u[2], b = bits.Sub64(x[2], qLimbs[2], b)
+	u[3], b = bits.Sub64(x[3], qLimbs[3], b)
+	_, b = bits.Sub64(hi, 0, b)
+
+	mask := -b // всі одиниці, якщо x < q (залишаємо x), інакше нулі (беремо x - q)
+	z[0] = (x[0] & mask) | (u[0] &^ mask)
+	z[1] = (x[1] & mask) | (u[1] &^ mask)
+	z[2] = (x[2] & mask) | (u[2] &^ mask)
+	z[3] = (x[3] & mask) | (u[3] &^ mask)
+}
+
+// feAdd - функція додавання z = x + y mod q
+func feAdd(z, x, y *fe) {
+	var t fe
+	var c uint64
+
+	t[0], c = bits.Add64(x[0], y[0], 0)
+	t[1], c = bits.Add64(x[1], y[1], c)
+	t[2], c = bits.Add64(x[2], y[2], c)
+	t[3], c = bits.Add64(x[3], y[3], c)
+
+	feReduce(z, &t, c)
+}
+
+// feMul - функція множення Монтгомері z = x*y/R mod q (CIOS)
+func feMul(z, x, y *fe) {
+	var t [6]uint64
+
+	for i := 0; i < 4; i++ {
+		var c, hi, lo, carry uint64
+
+		for j := 0; j < 4; j++ {
+			hi, lo = bits.Mul64(x[j], y[i])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j], c = lo, hi
+		}
+
+		t[4], carry = bits.Add64(t[4], c, 0)
+		t[5] = carry
+
+		m := t[0] * qInv
+
+		hi, lo = bits.Mul64(m, qLimbs[0])
+		_, carry = bits.Add64(lo, t[0], 0)
+		c = hi + carry
+
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(m, qLimbs[j])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j-1], c = lo, hi
+		}
+
+		t[3], carry = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + carry
+	}
+
+	feReduce(z, (*fe)(t[:4]), t[4])
+}
+
+// feExp5 - функція піднесення до ступеню 5 трьома множеннями (x^2, x^4, x^5)
+func feExp5(z, x *fe) {
+	var x2, x4 fe
+
+	feMul(&x2, x, x)
+	feMul(&x4, &x2, &x2)
+	feMul(z, &x4, x)
+}
+
+// feToMont - функція переведення лімів x < q у форму Монтгомері
+func feToMont(z, x *fe) {
+	feMul(z, x, &feR2)
+}
+
+// feFromMont - функція переведення з форми Монтгомері у звичайні ліми
+func feFromMont(z, x *fe) {
+	feMul(z, x, &feOne)
+}
+
+// feBytes - функція запису елемента (у формі Монтгомері) в 32-байтовий big-endian масив
+func feBytes(b *[32]byte, x *fe) {
+	var y fe
+	feFromMont(&y, x)
+
+	binary.BigEndian.PutUint64(b[0:8], y[3])
+	binary.BigEndian.PutUint64(b[8:16], y[2])
+	binary.BigEndian.PutUint64(b[16:24], y[1])
+	binary.BigEndian.PutUint64(b[24:32], y[0])
+}
+
+// ctConsts - константи перестановки для однієї ширини стану у формі Монтгомері
+type ctConsts struct {
+	c []fe
+	s []fe
+	m [][]fe
+	p [][]fe
+}
+
+var (
+	ctOnce   sync.Once
+	ctTables []ctConsts // за індексом ширина-2, як c.c, c.s, c.m, c.p
+)
+
+// ctConstants - функція отримання констант у формі Монтгомері (переводяться один раз при першому виклику)
+func ctConstants(width int) *ctConsts {
+	ctOnce.Do(func() {
+		vector := func(src []*big.Int) []fe {
+			dst := make([]fe, len(src))
+			for i, x := range src {
+				v := feFromWords(x)
+				feToMont(&dst[i], &v)
+			}
+			return dst
+		}
+
+		matrix := func(src [][]*big.Int) [][]fe {
+			dst := make([][]fe, len(src))
+			for i, row := range src {
+				dst[i] = vector(row)
+			}
+			return dst
+		}
+
+		ctTables = make([]ctConsts, len(c.c))
+		for i := range ctTables {
+			ctTables[i] = ctConsts{c: vector(c.c[i]), s: vector(c.s[i]), m: matrix(c.m[i]), p: matrix(c.p[i])}
+		}
+	})
+
+	return &ctTables[width-2]
+}
+
+// ctAddRoundKeys - функція додавання констант раунду, починаючи з індексу r
+func ctAddRoundKeys(state []fe, constants []fe, r int) {
+	for i := range state {
+		feAdd(&state[i], &state[i], &constants[r+i])
+	}
+}
+
+// ctExp5State - функція піднесення до ступеню 5 кожного елемента стану
+func ctExp5State(state []fe) {
+	for i := range state {
+		feExp5(&state[i], &state[i])
+	}
+}
+
+// ctMix - функція множення стану на матрицю: out[i] = sum_j matr[j][i]*state[j] (як у mix); scratch має довжину стану
+func ctMix(state []fe, matr [][]fe, scratch []fe) {
+	var prod fe
+
+	for i := range scratch {
+		scratch[i] = fe{}
+		for j := range state {
+			feMul(&prod, &matr[j][i], &state[j])
+			feAdd(&scratch[i], &scratch[i], &prod)
+		}
+	}
+
+	copy(state, scratch)
+}
+
+// permuteCT - перестановка Poseidon зі сталим часом виконання над станом у формі Монтгомері (ширина 2..17);
+// послідовність операцій збігається з permute, а кількість раундів залежить лише від ширини
+func permuteCT(state []fe, scratch []fe) {
+	t := len(state)
+	nRoundsF := NROUNDSF
+	nRoundsP := NROUNDSP[t-2]
+	k := ctConstants(t)
+
+	ctAddRoundKeys(state, k.c, 0)
+
+	for i := 0; i < nRoundsF/2-1; i++ {
+		ctExp5State(state)
+		ctAddRoundKeys(state, k.c, (i+1)*t)
+		ctMix(state, k.m, scratch)
+	}
+
+	ctExp5State(state)
+	ctAddRoundKeys(state, k.c, (nRoundsF/2)*t)
+	ctMix(state, k.p, scratch)
+
+	var prod, newState0 fe
+
+	for i := 0; i < nRoundsP; i++ {
+		feExp5(&state[0], &state[0])
+		feAdd(&state[0], &state[0], &k.c[(nRoundsF/2+1)*t+i])
+
+		newState0 = fe{}
+		for j := range state {
+			feMul(&prod, &k.s[(t*2-1)*i+j], &state[j])
+			feAdd(&newState0, &newState0, &prod)
+		}
+
+		for j := 1; j < t; j++ {
+			feMul(&prod, &state[0], &k.s[(t*2-1)*i+t+j-1])
+			feAdd(&state[j], &state[j], &prod)
+		}
+
+		state[0] = newState0
+	}
+
+	for i := 0; i < nRoundsF/2-1; i++ {
+		ctExp5State(state)
+		ctAddRoundKeys(state, k.c, (nRoundsF/2+1)*t+nRoundsP+i*t)
+		ctMix(state, k.m, scratch)
+	}
+
+	ctExp5State(state)
+	ctMix(state, k.m, scratch)
+}
+
+// HashConstantTime - функція гешування 1..16 елементів поля зі сталим часом виконання: результат збігається з Hash,
+// але арифметика виконується над лімами фіксованої довжини без залежних від значень розгалужень, тому час не залежить
+// від вхідних значень (лише від їх кількості). Призначена для секретних входів (ключі нуліфікаторів, маскуючі множники).
+// Перетворення *big.Int в Element і назад не є сталочасовими, тому секрети потрібно зберігати як Element.
+// Повертає ErrInputsLength для неправильної кількості елементів і ErrInvalidInput, якщо якийсь елемент не менший за q
+// (перевірка виконується для всіх елементів без раннього виходу).
+func HashConstantTime(inputs ...Element) (Digest, error) {
+	var digest Digest
+
+	if len(inputs) == 0 || len(inputs) > INPUTS {
+		return digest, ErrInputsLength
+	}
+
+	var buf [2 * (INPUTS + 1)]fe
+	state := buf[:len(inputs)+1]
+	scratch := buf[INPUTS+1 : INPUTS+1+len(state)]
+
+	valid := uint64(1)
+
+	for i := range inputs {
+		x := feFromBytes((*[32]byte)(&inputs[i]))
+		valid &= feLessThanQ(&x)
+		feToMont(&state[i+1], &x)
+	}
+
+	if valid == 0 {
+		return digest, ErrInvalidInput
+	}
+
+	permuteCT(state, scratch)
+	feBytes((*[32]byte)(&digest), &state[0])
+
+	return digest, nil
+}
@@ -0,0 +1,178 @@
+package poseidon
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+// randomElement - функція генерації випадкового елемента поля з детермінованого генератора
+func randomElement(rnd *rand.Rand) Element {
+	e, _ := NewElement(new(big.Int).Rand(rnd, q))
+	return e
+}
+
+func TestFieldArithmetic(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	qm1 := new(big.Int).Sub(q, big.NewInt(1))
+
+	values := []*big.Int{big.NewInt(0), big.NewInt(1), qm1, new(big.Int).Rsh(q, 1)}
+	for i := 0; i < 200; i++ {
+		values = append(values, new(big.Int).Rand(rnd, q))
+	}
+
+	toFe := func(x *big.Int) fe {
+		v := feFromWords(x)
+		var m fe
+		feToMont(&m, &v)
+		return m
+	}
+
+	toBig := func(x *fe) *big.Int {
+		var b [32]byte
+		feBytes(&b, x)
+		return new(big.Int).SetBytes(b[:])
+	}
+
+	for i, x := range values {
+		y := values[(i*7+3)%len(values)]
+		fx, fy := toFe(x), toFe(y)
+
+		var sum, prod, pow fe
+		feAdd(&sum, &fx, &fy)
+		feMul(&prod, &fx, &fy)
+		feExp5(&pow, &fx)
+
+		if want := new(big.Int).Add(x, y); toBig(&sum).Cmp(want.Mod(want, q)) != 0 {
+			t.Fatalf("%s + %s = %s, expected %s", x, y, toBig(&sum), want)
+		}
+
+		if want := new(big.Int).Mul(x, y); toBig(&prod).Cmp(want.Mod(want, q)) != 0 {
+			t.Fatalf("%s * %s = %s, expected %s", x, y, toBig(&prod), want)
+		}
+
+		if want := new(big.Int).Exp(x, big5int, q); toBig(&pow).Cmp(want) != 0 {
+			t.Fatalf("%s^5 = %s, expected %s", x, toBig(&pow), want)
+		}
+	}
+}
+
+func TestHashConstantTimeMatchesHash(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	qm1, _ := NewElement(new(big.Int).Sub(q, big.NewInt(1)))
+
+	for n := 1; n <= INPUTS; n++ {
+		for _, fill := range []func(i int) Element{
+			func(int) Element { return Element{} },
+			func(int) Element { return qm1 },
+			func(int) Element { return randomElement(rnd) },
+		} {
+			inputs := make([]Element, n)
+			input := make([]*big.Int, n)
+			for i := range inputs {
+				inputs[i] = fill(i)
+				input[i] = inputs[i].BigInt()
+			}
+
+			digest, err := HashConstantTime(inputs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := Hash(input); digest.BigInt().Cmp(want) != 0 {
+				t.Fatalf("width %d: HashConstantTime is %s, Hash is %s", n+1, digest, want)
+			}
+		}
+	}
+
+	var invalid Element
+	q.FillBytes(invalid[:])
+
+	if _, err := HashConstantTime(Element{}, invalid); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("element q returned %v", err)
+	}
+
+	if _, err := HashConstantTime(make([]Element, INPUTS+1)...); !errors.Is(err, ErrInputsLength) {
+		t.Fatalf("17 elements returned %v", err)
+	}
+}
+
+// welchT - функція обчислення t-статистики Велча для двох вибірок
+func welchT(a, b []float64) float64 {
+	stats := func(x []float64) (mean, variance float64) {
+		for _, v := range x {
+			mean += v
+		}
+		mean /= float64(len(x))
+
+		for _, v := range x {
+			variance += (v - mean) * (v - mean)
+		}
+		return mean, variance / float64(len(x)-1)
+	}
+
+	ma, va := stats(a)
+	mb, vb := stats(b)
+
+	return (ma - mb) / math.Sqrt(va/float64(len(a))+vb/float64(len(b)))
+}
+
+// TestHashConstantTimeLeakage - статистичний тест витоку часу в стилі dudect: вимірювання для фіксованого входу (нулі)
+// і для випадкових входів перемежовуються у випадковому порядку, викиди (найповільніші 10% вимірювань) відкидаються,
+// і t-тест Велча перевіряє, що середній час обох класів не розрізняється
+func TestHashConstantTimeLeakage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping timing test in short mode")
+	}
+
+	const (
+		measurements = 20000
+		threshold    = 10 // |t| > 10 - впевнене свідчення витоку (поріг dudect)
+	)
+
+	rnd := rand.New(rand.NewSource(3))
+
+	inputs := make([][]Element, measurements)
+	classes := make([]int, measurements)
+
+	for i := range inputs {
+		classes[i] = rnd.Intn(2)
+		if classes[i] == 0 { // фіксований вхід зберігається в окремих масивах, щоб обидва класи однаково працювали з кешем
+			inputs[i] = []Element{{}, {}}
+		} else {
+			inputs[i] = []Element{randomElement(rnd), randomElement(rnd)}
+		}
+	}
+
+	times := make([]float64, measurements)
+
+	HashConstantTime(inputs[0]...) // ініціалізація констант не повинна потрапити у вимірювання
+
+	for i := range inputs {
+		start := time.Now()
+		HashConstantTime(inputs[i]...)
+		times[i] = float64(time.Since(start))
+	}
+
+	sorted := append([]float64(nil), times...)
+	sort.Float64s(sorted)
+	cutoff := sorted[len(sorted)*9/10]
+
+	var samples [2][]float64
+	for i, d := range times {
+		if d <= cutoff {
+			samples[classes[i]] = append(samples[classes[i]], d)
+		}
+	}
+
+	tValue := welchT(samples[0], samples[1])
+	t.Logf("fixed vs random inputs: |t| = %.2f over %d+%d measurements", math.Abs(tValue), len(samples[0]), len(samples[1]))
+
+	if math.Abs(tValue) > threshold {
+		t.Fatalf("timing of HashConstantTime depends on input values: |t| = %.2f > %d", math.Abs(tValue), threshold)
+	}
+}
@@ -0,0 +1,27 @@
+// Package poseidon - реалізація гешування Poseidon над скалярним полем BN254 з константами circomlib (сумісна з
+// go-iden3-crypto), командний рядок poseidon (Run) і HTTP/JSON та gRPC сервіси (RunServe), які використовують
+// програми poseidon і poseidond.
+//
+// # Паралельне використання
+//
+// Функції пакета можна викликати одночасно з будь-якої кількості горутин:
+// Hash, HashWithOptions, HashBytes, HashBytesWithOptions, HashBytesStrict, HashStruct, HashElements, HashConstantTime, Permute, InversePermute,
+// HashTrace, EstimateCost, PRF, DeriveKeys, MAC, VerifyMAC, Encrypt, Decrypt, ECDHSharedKey, MerkleRoot, MerkleProof, VerifyMerkleProof,
+// а також конструктори і декодери Element.
+//
+// Вхідні значення (*big.Int, масиви, структури) лише читаються: функції копіюють їх у власний стан і не змінюють,
+// тому одні й ті самі входи можна передавати з кількох горутин, якщо викликаюча сторона не змінює їх під час виклику.
+// Результат кожного виклику - новий об'єкт, який не розділяється з іншими викликами.
+//
+// Внутрішні горутини перестановки (addRoundKeys, exp5state, mix) працюють з окремими елементами стану одного виклику;
+// тимчасові об'єкти з bigIntPool не виходять за межі exp5; константи ініціалізуються в init,
+// а їх форма Монтгомері, обернені матриці і параметри профілів сумісності - один раз через sync.Once.
+//
+// Element - значення фіксованого розміру, його методи з отримувачем-значенням безпечні для паралельного виклику;
+// UnmarshalText, UnmarshalBinary і Scan змінюють елемент і потребують зовнішньої синхронізації, як будь-який запис.
+//
+// Hasher і BytesHasher мають змінний внутрішній стан і не призначені для одночасного використання:
+// кожна горутина має використовувати власний екземпляр.
+//
+// Гарантії перевіряє TestConcurrentAPIs (race_test.go) під детектором гонок: go test -race ./...
+package poseidon
@@ -0,0 +1,266 @@
+package poseidon
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var ErrInvalidEncoding = errors.New("poseidon: invalid field element encoding")
+
+// ElementSize - розмір канонічного бінарного кодування елемента поля в байтах
+const ElementSize = 32
+
+// Element - елемент поля (значення менше q) у вигляді 32-байтового big-endian масиву. Усі конструктори і декодери
+// відхиляють неканонічні значення (не менші за q), тому два однакових елементи завжди мають однакове кодування
+// і їх можна порівнювати оператором ==. Нульове значення - елемент 0. Неканонічне значення можна отримати лише
+// прямим перетворенням масиву (Element(b)); MarshalText, MarshalBinary і Value для нього повертають ErrInvalidInput.
+type Element [ElementSize]byte
+
+// Digest - результат гешування (елемент поля) з тими самими кодуваннями, що й Element
+type Digest = Element
+
+// NewElement - функція створення елемента поля з *big.Int; повертає ErrInvalidInput, якщо x не в межах [0, q)
+func NewElement(x *big.Int) (Element, error) {
+	var e Element
+
+	if !inField(x) {
+		return e, ErrInvalidInput
+	}
+
+	x.FillBytes(e[:])
+
+	return e, nil
+}
+
+// ElementFromUint64 - функція створення елемента поля з uint64 (будь-яке uint64 менше q)
+func ElementFromUint64(x uint64) Element {
+	e, _ := NewElement(new(big.Int).SetUint64(x))
+
+	return e
+}
+
+// ElementFromBytes - функція декодування елемента з 32-байтового big-endian масиву
+func ElementFromBytes(b []byte) (Element, error) {
+	var e Element
+
+	if len(b) != ElementSize {
+		return e, ErrInvalidEncoding
+	}
+
+	copy(e[:], b)
+
+	return e, e.check()
+}
+
+// ElementFromBytesLE - функція декодування елемента з 32-байтового little-endian масиву
+func ElementFromBytesLE(b []byte) (Element, error) {
+	if len(b) != ElementSize {
+		return Element{}, ErrInvalidEncoding
+	}
+
+	be := make([]byte, ElementSize)
+	copy(be, b)
+	reverseBytes(be)
+
+	return ElementFromBytes(be)
+}
+
+// ElementFromString - функція розбору елемента в десятковому вигляді або в шістнадцятковому з префіксом 0x (до 64 цифр)
+func ElementFromString(s string) (Element, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return ElementFromHex(s)
+	}
+
+	if s == "" || strings.Trim(s, "0123456789") != "" { // лише цифри, без знака і пробілів
+		return Element{}, ErrInvalidEncoding
+	}
+
+	x, _ := new(big.Int).SetString(s, 10)
+
+	return NewElement(x)
+}
+
+// ElementFromHex - функція розбору елемента в шістнадцятковому вигляді з префіксом 0x (1..64 цифри)
+func ElementFromHex(s string) (Element, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return Element{}, ErrInvalidEncoding
+	}
+
+	digits := s[2:]
+	if len(digits) == 0 || len(digits) > 2*ElementSize {
+		return Element{}, ErrInvalidEncoding
+	}
+
+	if len(digits)%2 == 1 {
+		digits = "0" + digits
+	}
+
+	b, err := hex.DecodeString(digits)
+	if err != nil {
+		return Element{}, ErrInvalidEncoding
+	}
+
+	return NewElement(new(big.Int).SetBytes(b))
+}
+
+// ElementFromBase64 - функція декодування елемента з base64 (стандартний алфавіт) 32-байтового big-endian масиву
+func ElementFromBase64(s string) (Element, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return Element{}, ErrInvalidEncoding
+	}
+
+	return ElementFromBytes(b)
+}
+
+// HashElements - функція гешування 1..16 елементів поля з Hash; повертає ErrInputsLength для неправильної кількості
+// елементів і ErrInvalidInput, якщо якийсь елемент не менший за q (як Hash з iden3)
+func HashElements(inputs ...Element) (Digest, error) {
+	if len(inputs) == 0 || len(inputs) > INPUTS {
+		return Digest{}, ErrInputsLength
+	}
+
+	input := make([]*big.Int, len(inputs))
+	for i, e := range inputs {
+		if err := e.check(); err != nil {
+			return Digest{}, err
+		}
+
+		input[i] = e.BigInt()
+	}
+
+	return NewElement(Hash(input))
+}
+
+// check - функція перевірки, що значення елемента менше q
+func (e Element) check() error {
+	if !inField(e.BigInt()) {
+		return ErrInvalidInput
+	}
+
+	return nil
+}
+
+// BigInt - функція перетворення елемента в новий *big.Int
+func (e Element) BigInt() *big.Int {
+	return new(big.Int).SetBytes(e[:])
+}
+
+// Bytes - функція отримання 32-байтового big-endian кодування
+func (e Element) Bytes() []byte {
+	return append([]byte(nil), e[:]...)
+}
+
+// BytesLE - функція отримання 32-байтового little-endian кодування
+func (e Element) BytesLE() []byte {
+	b := e.Bytes()
+	reverseBytes(b)
+
+	return b
+}
+
+// Hex - функція отримання шістнадцяткового кодування з префіксом 0x (завжди 64 цифри); Hex, String і Base64
+// не перевіряють значення, для перевіреного кодування - MarshalText і MarshalBinary
+func (e Element) Hex() string {
+	return "0x" + hex.EncodeToString(e[:])
+}
+
+// String - функція отримання десяткового кодування
+func (e Element) String() string {
+	return e.BigInt().String()
+}
+
+// Base64 - функція отримання base64-кодування 32-байтового big-endian масиву
+func (e Element) Base64() string {
+	return base64.StdEncoding.EncodeToString(e[:])
+}
+
+// MarshalText - текстове кодування (encoding.TextMarshaler, також використовується в JSON): десятковий рядок
+func (e Element) MarshalText() ([]byte, error) {
+	if err := e.check(); err != nil {
+		return nil, err
+	}
+
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText - декодування з десяткового рядка або шістнадцяткового з префіксом 0x
+func (e *Element) UnmarshalText(text []byte) error {
+	v, err := ElementFromString(string(text))
+	if err != nil {
+		return err
+	}
+
+	*e = v
+
+	return nil
+}
+
+// MarshalBinary - бінарне кодування (encoding.BinaryMarshaler): 32 байти big-endian
+func (e Element) MarshalBinary() ([]byte, error) {
+	if err := e.check(); err != nil {
+		return nil, err
+	}
+
+	return e.Bytes(), nil
+}
+
+// UnmarshalBinary - декодування з 32 байтів big-endian
+func (e *Element) UnmarshalBinary(data []byte) error {
+	v, err := ElementFromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	*e = v
+
+	return nil
+}
+
+// Value - значення для бази даних (driver.Valuer): 32 байти big-endian (BYTEA, BLOB, BINARY(32))
+func (e Element) Value() (driver.Value, error) {
+	if err := e.check(); err != nil {
+		return nil, err
+	}
+
+	return e.Bytes(), nil
+}
+
+// Scan - читання з бази даних (sql.Scanner): 32 байти big-endian або текст у десятковому чи шістнадцятковому вигляді.
+// Драйвери часто повертають текстові й числові стовпці як []byte, тому []byte довжини, відмінної від ElementSize,
+// розбирається як текст; значення рівно з 32 байтів завжди вважається бінарним.
+func (e *Element) Scan(src interface{}) error {
+	var v Element
+	var err error
+
+	switch src := src.(type) {
+	case []byte:
+		if len(src) == ElementSize {
+			v, err = ElementFromBytes(src)
+		} else {
+			v, err = ElementFromString(string(src))
+		}
+	case string:
+		v, err = ElementFromString(src)
+	case int64:
+		if src < 0 {
+			return ErrInvalidInput
+		}
+		v = ElementFromUint64(uint64(src))
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidEncoding, src)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*e = v
+
+	return nil
+}
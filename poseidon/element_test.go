@@ -0,0 +1,176 @@
+package poseidon
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler     = Element{}
+	_ encoding.TextUnmarshaler   = (*Element)(nil)
+	_ encoding.BinaryMarshaler   = Element{}
+	_ encoding.BinaryUnmarshaler = (*Element)(nil)
+	_ driver.Valuer              = Element{}
+	_ sql.Scanner                = (*Element)(nil)
+)
+
+func TestElementEncodings(t *testing.T) {
+	x := new(big.Int).Sub(q, big.NewInt(1))
+
+	e, err := NewElement(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.BigInt().Cmp(x) != 0 || e.String() != x.String() {
+		t.Fatalf("element is %s, expected %s", e, x)
+	}
+
+	if e.Hex() != fmt.Sprintf("0x%064x", x) {
+		t.Fatalf("hex is %s", e.Hex())
+	}
+
+	decoders := map[string]func() (Element, error){
+		"bytes":   func() (Element, error) { return ElementFromBytes(e.Bytes()) },
+		"bytesLE": func() (Element, error) { return ElementFromBytesLE(e.BytesLE()) },
+		"hex":     func() (Element, error) { return ElementFromHex(e.Hex()) },
+		"decimal": func() (Element, error) { return ElementFromString(e.String()) },
+		"string":  func() (Element, error) { return ElementFromString(e.Hex()) },
+		"base64":  func() (Element, error) { return ElementFromBase64(e.Base64()) },
+	}
+
+	for name, decode := range decoders {
+		got, err := decode()
+		if err != nil || got != e {
+			t.Fatalf("%s: round trip returned %s, %v", name, got, err)
+		}
+	}
+
+	small := ElementFromUint64(1)
+	if small.Hex() != "0x"+strings.Repeat("0", 63)+"1" || small.BytesLE()[0] != 1 || len(small.Bytes()) != ElementSize {
+		t.Fatalf("fixed-width encodings of 1 are %s, %x", small.Hex(), small.BytesLE())
+	}
+
+	if short, _ := ElementFromHex("0x2"); short != ElementFromUint64(2) {
+		t.Fatalf("short hex is decoded as %s", short)
+	}
+}
+
+func TestElementRejectsNonCanonical(t *testing.T) {
+	qBytes := q.FillBytes(make([]byte, ElementSize))
+	qLE := q.FillBytes(make([]byte, ElementSize))
+	reverseBytes(qLE)
+
+	tests := map[string]func() (Element, error){
+		"big.Int q":      func() (Element, error) { return NewElement(q) },
+		"big.Int -1":     func() (Element, error) { return NewElement(big.NewInt(-1)) },
+		"big.Int nil":    func() (Element, error) { return NewElement(nil) },
+		"bytes q":        func() (Element, error) { return ElementFromBytes(qBytes) },
+		"bytesLE q":      func() (Element, error) { return ElementFromBytesLE(qLE) },
+		"decimal q":      func() (Element, error) { return ElementFromString(q.String()) },
+		"hex q":          func() (Element, error) { return ElementFromHex(fmt.Sprintf("0x%064x", q)) },
+		"hex 2^256-1":    func() (Element, error) { return ElementFromHex("0x" + strings.Repeat("f", 64)) },
+		"base64 q":       func() (Element, error) { return ElementFromBase64(base64.StdEncoding.EncodeToString(qBytes)) },
+		"short bytes":    func() (Element, error) { return ElementFromBytes(make([]byte, 31)) },
+		"long hex":       func() (Element, error) { return ElementFromHex("0x" + strings.Repeat("0", 65)) },
+		"hex no prefix":  func() (Element, error) { return ElementFromHex("ff") },
+		"empty":          func() (Element, error) { return ElementFromString("") },
+		"signed decimal": func() (Element, error) { return ElementFromString("+1") },
+		"bad base64":     func() (Element, error) { return ElementFromBase64("!") },
+	}
+
+	for name, decode := range tests {
+		if _, err := decode(); !errors.Is(err, ErrInvalidInput) && !errors.Is(err, ErrInvalidEncoding) {
+			t.Fatalf("%s: expected an error, got %v", name, err)
+		}
+	}
+
+	// елемент, отриманий перетворенням масиву, кодувальники не серіалізують, бо декодери його не приймуть
+	invalid := Element(qBytes)
+
+	encoders := map[string]func() (interface{}, error){
+		"text":   func() (interface{}, error) { return invalid.MarshalText() },
+		"binary": func() (interface{}, error) { return invalid.MarshalBinary() },
+		"value":  func() (interface{}, error) { return invalid.Value() },
+		"json":   func() (interface{}, error) { return json.Marshal(invalid) },
+	}
+
+	for name, encode := range encoders {
+		if _, err := encode(); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("%s encoding of q returned %v", name, err)
+		}
+	}
+}
+
+func TestElementJSONAndSQL(t *testing.T) {
+	type record struct {
+		Root Digest    `json:"root"`
+		Path []Element `json:"path"`
+	}
+
+	in := record{Root: ElementFromUint64(7), Path: []Element{ElementFromUint64(1), ElementFromUint64(2)}}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"root":"7","path":["1","2"]}` {
+		t.Fatalf("JSON is %s", data)
+	}
+
+	var out record
+	if err := json.Unmarshal([]byte(`{"root":"0x7","path":["1","2"]}`), &out); err != nil || out.Root != in.Root || len(out.Path) != 2 {
+		t.Fatalf("JSON decoded as %+v, %v", out, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"root":"`+q.String()+`"}`), &out); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("JSON with q returned %v", err)
+	}
+
+	value, _ := in.Root.Value()
+
+	var scanned Element
+	for _, src := range []interface{}{value, "7", "0x07", int64(7), []byte("7"), []byte("0x07")} {
+		scanned = Element{}
+		if err := scanned.Scan(src); err != nil || scanned != in.Root {
+			t.Fatalf("Scan(%v) returned %s, %v", src, scanned, err)
+		}
+	}
+
+	for _, src := range []interface{}{nil, 1.5, int64(-1), q.String(), []byte{1}, []byte(q.String()), []byte("0x")} {
+		if err := scanned.Scan(src); err == nil {
+			t.Fatalf("Scan(%v) accepted a non-canonical value", src)
+		}
+	}
+}
+
+func TestHashElements(t *testing.T) {
+	digest, err := HashElements(ElementFromUint64(1), ElementFromUint64(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := Hash([]*big.Int{big.NewInt(1), big.NewInt(2)}); digest.BigInt().Cmp(want) != 0 {
+		t.Fatalf("digest is %s, expected %s", digest, want)
+	}
+
+	if _, err := HashElements(); !errors.Is(err, ErrInputsLength) {
+		t.Fatalf("empty input returned %v", err)
+	}
+
+	var invalid Element
+	q.FillBytes(invalid[:])
+
+	if _, err := HashElements(ElementFromUint64(1), invalid); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("element q returned %v", err)
+	}
+}
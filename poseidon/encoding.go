@@ -0,0 +1,201 @@
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// Padding - спосіб доповнення повідомлення при перетворенні масиву байтів в елементи поля
+type Padding int
+
+const (
+	// PaddingZero - legacy-доповнення нулями без кодування довжини (сумісне з iden3). Не стійке до колізій:
+	// повідомлення, які відрізняються лише нульовими байтами в кінці (наприклад, "abc" і "abc\x00"), мають однаковий геш.
+	PaddingZero Padding = iota
+	// PaddingLengthPrefix - першим елементом поглинається довжина повідомлення в байтах, далі блоки з нулями в кінці.
+	// Кодування ін'єктивне, тому стійке до колізій доповнення.
+	PaddingLengthPrefix
+	// Padding10 - першим поглинається тег padding10Tag, після повідомлення дописується біт 1, далі нулі (доповнення 10*).
+	// Без тегу кодування не було б стійким до колізій: перший кадр губки не має окремого елемента для гешу, тому
+	// повідомлення, перші 31 байт якого - геш першого кадру іншого повідомлення, мало б той самий геш.
+	Padding10
+	// PaddingStrict - поєднання PaddingLengthPrefix і Padding10 з тегом домену: першим поглинається strictTag + довжина,
+	// тому геш неможливо продовжити без знання повідомлення, доповнення однозначне навіть без довжини, а перший елемент
+	// не може збігтися з першим елементом жодного іншого кодування. Використовується в HashBytesStrict.
+	PaddingStrict
+)
+
+// strictTag - тег домену PaddingStrict (2^253): перший елемент strict-кодування strictTag + довжина не менший за 2^253,
+// а блоки всіх кодувань (CHUNK248, CHUNK253) і довжина PaddingLengthPrefix менші, тому жоден кадр іншого кодування
+// не збігається з першим кадром strict-кодування
+var strictTag = new(big.Int).Lsh(big.NewInt(1), CHUNK253)
+
+// padding10Tag - тег домену Padding10 (2^253 + 2^128): не менший за 2^253, тому не збігається з жодним блоком,
+// і більший за strictTag + будь-яка довжина uint64, тому не збігається з першим елементом strict-кодування.
+// Геш кадру дорівнює тегу з імовірністю 1/q, тому геш першого кадру не можна видати за початок іншого повідомлення.
+var padding10Tag = new(big.Int).Add(strictTag, new(big.Int).Lsh(big.NewInt(1), 128))
+
+// ByteOrder - порядок байтів (і бітів) при перетворенні блоку повідомлення в елемент поля
+type ByteOrder int
+
+const (
+	BigEndian    ByteOrder = iota // перший байт блоку - старший (legacy, як у iden3)
+	LittleEndian                  // перший байт блоку - молодший
+)
+
+// Розміри блоків у бітах, на які розбивається повідомлення
+const (
+	CHUNK248 = SBLOCK * 8 // 31 байт (legacy)
+	CHUNK253 = 253        // найбільший розмір блоку, для якого будь-яке значення менше q
+)
+
+var ErrInvalidOptions = errors.New("poseidon: invalid byte encoding options")
+
+// HashBytesOptions - параметри кодування масиву байтів в елементи поля для HashBytesWithOptions.
+// Нульове значення відповідає legacy-кодуванню HashBytes.
+type HashBytesOptions struct {
+	Padding   Padding   // спосіб доповнення
+	ByteOrder ByteOrder // порядок байтів в блоці
+	ChunkBits int       // розмір блоку в бітах: CHUNK248 (0 - за замовчуванням) або CHUNK253
+}
+
+// encodeChunks - функція розбиття перших nbits бітів масиву data на блоки по chunkBits бітів;
+// останній неповний блок доповнюється нульовими бітами в кінці
+func encodeChunks(data []byte, nbits, chunkBits int, order ByteOrder) []*big.Int {
+	elems := make([]*big.Int, (nbits+chunkBits-1)/chunkBits)
+
+	for i := range elems {
+		start := i * chunkBits
+
+		if chunkBits%8 == 0 { // блоки вирівняні по байтах - копіюємо байти блоку в буфер
+			buf := make([]byte, chunkBits/8)
+			copy(buf, data[start/8:minInt(len(data), (start+chunkBits)/8)])
+
+			if order == LittleEndian {
+				reverseBytes(buf)
+			}
+			elems[i] = new(big.Int).SetBytes(buf)
+
+			continue
+		}
+
+		elems[i] = new(big.Int)
+		for j := 0; j < chunkBits && start+j < nbits; j++ { // блоки не вирівняні по байтах - збираємо елемент по бітах
+			pos := start + j
+
+			var bit uint
+			if order == LittleEndian {
+				bit = uint(data[pos/8]>>(pos%8)) & 1
+				elems[i].SetBit(elems[i], j, bit)
+			} else {
+				bit = uint(data[pos/8]>>(7-pos%8)) & 1
+				elems[i].SetBit(elems[i], chunkBits-1-j, bit)
+			}
+		}
+	}
+
+	return elems
+}
+
+// encodeBytes - функція перетворення масиву байтів msg в масив елементів поля відповідно до параметрів opts
+func encodeBytes(msg []byte, opts HashBytesOptions) ([]*big.Int, error) {
+	chunkBits := opts.ChunkBits
+	if chunkBits == 0 {
+		chunkBits = CHUNK248
+	}
+
+	if chunkBits != CHUNK248 && chunkBits != CHUNK253 {
+		return nil, ErrInvalidOptions
+	}
+
+	if opts.ByteOrder != BigEndian && opts.ByteOrder != LittleEndian {
+		return nil, ErrInvalidOptions
+	}
+
+	data := msg
+	nbits := len(msg) * 8
+
+	var elems []*big.Int
+
+	switch opts.Padding {
+	case PaddingZero:
+	case PaddingLengthPrefix:
+		elems = append(elems, big.NewInt(int64(len(msg))))
+	case Padding10, PaddingStrict:
+		if opts.Padding == PaddingStrict {
+			elems = append(elems, strictLength(uint64(len(msg))))
+		} else {
+			elems = append(elems, new(big.Int).Set(padding10Tag))
+		}
+
+		marker := byte(0x80) // біт 1 - перший біт після повідомлення в порядку читання бітів
+		if opts.ByteOrder == LittleEndian {
+			marker = 0x01
+		}
+
+		data = append(append(make([]byte, 0, len(msg)+1), msg...), marker)
+		nbits++
+	default:
+		return nil, ErrInvalidOptions
+	}
+
+	return append(elems, encodeChunks(data, nbits, chunkBits, opts.ByteOrder)...), nil
+}
+
+// HashBytesWithOptions - функція гешування масиву байтів з вибраним кодуванням в елементи поля.
+// Елементи поглинаються тією ж губкою, що й у HashBytes; з нульовими opts результат збігається з HashBytes.
+func HashBytesWithOptions(msg []byte, opts HashBytesOptions) (*big.Int, error) {
+	elems, err := encodeBytes(msg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return absorbElements(elems), nil
+}
+
+// strictLength - функція першого елемента PaddingStrict: довжина повідомлення з тегом домену strictTag
+func strictLength(length uint64) *big.Int {
+	return new(big.Int).Add(strictTag, new(big.Int).SetUint64(length))
+}
+
+// HashBytesStrict - функція гешування масиву байтів, стійка до колізій доповнення і до продовження гешу:
+// повідомлення кодується з PaddingStrict (довжина з тегом домену першим елементом і доповнення 10*), тому результат
+// відрізняється від HashBytes і HashBytesWithOptions з іншими кодуваннями для будь-яких повідомлень.
+func HashBytesStrict(msg []byte) *big.Int {
+	hash, _ := HashBytesWithOptions(msg, HashBytesOptions{Padding: PaddingStrict}) // параметри завжди коректні
+
+	return hash
+}
+
+// parseElement - функція розбору елемента поля в десятковому або шістнадцятковому (з префіксом 0x) вигляді
+func parseElement(s string) (*big.Int, error) {
+	base := 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s, base = s[2:], 16
+	}
+
+	x, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return nil, errors.New("not a decimal or 0x-prefixed hexadecimal number")
+	}
+
+	if !inField(x) {
+		return nil, ErrInvalidInput
+	}
+
+	return x, nil
+}
+
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
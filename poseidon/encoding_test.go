@@ -0,0 +1,294 @@
+package poseidon
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+func testBytes(n int) []byte {
+	msg := make([]byte, n)
+	for i := range msg {
+		msg[i] = byte(i*31 + 7)
+	}
+	return msg
+}
+
+func TestHashBytesLegacyMatchesLibrary(t *testing.T) {
+	for _, n := range []int{1, 30, 31, 32, 495, 496, 497, 961, 992, 1000} {
+		msg := testBytes(n)
+
+		libHash, err := poseidon.HashBytes(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if hash := HashBytes(msg); hash.Cmp(libHash) != 0 {
+			t.Fatalf("length %d: HashBytes is %s, library is %s", n, hash, libHash)
+		}
+
+		hash, err := HashBytesWithOptions(msg, HashBytesOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash.Cmp(libHash) != 0 {
+			t.Fatalf("length %d: default options hash is %s, library is %s", n, hash, libHash)
+		}
+	}
+}
+
+// TestHashBytesFrameBoundary - регресійні вектори для довжин 31 * (16 + 15m) байтів, на яких останній повний блок
+// заповнює кадр губки: HashBytes не гешує такий кадр ще раз (як iden3), попередня версія гешувала (див. CHANGELOG.md)
+func TestHashBytesFrameBoundary(t *testing.T) {
+	vectors := []struct {
+		n        int
+		hash     string
+		previous string
+	}{
+		{496, "17319235305578896743578667874111008901611331197510574609330145856925184408931", "17918389751575796662758189026936885073172372672460633309699730923030074383643"},
+		{961, "20224646126045342960858932231097646105202998396226358936947798272057648580428", "12045732107295736044568825464941918137180261236132216932236274039594623608006"},
+	}
+
+	for _, v := range vectors {
+		hash := HashBytes(testBytes(v.n))
+		if hash.String() != v.hash {
+			t.Fatalf("length %d: HashBytes is %s, expected %s", v.n, hash, v.hash)
+		}
+
+		// попередня версія гешувала ще раз кадр [hash, 0, ..., 0]
+		frame := make([]*big.Int, INPUTS)
+		frame[0] = hash
+		for j := 1; j < INPUTS; j++ {
+			frame[j] = new(big.Int)
+		}
+
+		if previous := Hash(frame); previous.String() != v.previous {
+			t.Fatalf("length %d: previous HashBytes is %s, expected %s", v.n, previous, v.previous)
+		}
+	}
+}
+
+func TestAbsorbElementsMatchesSpongeHash(t *testing.T) {
+	for _, n := range []int{1, 15, 16, 17, 31, 46, 100} {
+		elems := make([]*big.Int, n)
+		for i := range elems {
+			elems[i] = big.NewInt(int64(i + 1))
+		}
+
+		libHash, err := poseidon.SpongeHash(elems)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if hash := absorbElements(elems); hash.Cmp(libHash) != 0 {
+			t.Fatalf("%d elements: absorbElements is %s, SpongeHash is %s", n, hash, libHash)
+		}
+	}
+}
+
+func TestEncodeBytes(t *testing.T) {
+	msg := []byte("abc")
+
+	tests := []struct {
+		name string
+		opts HashBytesOptions
+		want []string // елементи в шістнадцятковому вигляді
+	}{
+		{name: "legacy", opts: HashBytesOptions{}, want: []string{"616263" + zeros(28)}},
+		{name: "little-endian", opts: HashBytesOptions{ByteOrder: LittleEndian}, want: []string{"636261"}},
+		{name: "length prefix", opts: HashBytesOptions{Padding: PaddingLengthPrefix}, want: []string{"3", "616263" + zeros(28)}},
+		{name: "10* padding", opts: HashBytesOptions{Padding: Padding10}, want: []string{padding10Tag.Text(16), "61626380" + zeros(27)}},
+		{name: "10* little-endian", opts: HashBytesOptions{Padding: Padding10, ByteOrder: LittleEndian}, want: []string{padding10Tag.Text(16), "1636261"}},
+		{name: "253-bit chunks", opts: HashBytesOptions{ChunkBits: CHUNK253}, want: []string{"c2c4c6" + zeros(28) + "0"}},
+		{name: "253-bit little-endian", opts: HashBytesOptions{ChunkBits: CHUNK253, ByteOrder: LittleEndian}, want: []string{"636261"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elems, err := encodeBytes(msg, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(elems) != len(tt.want) {
+				t.Fatalf("expected %d elements, got %d", len(tt.want), len(elems))
+			}
+
+			for i, e := range elems {
+				if e.Text(16) != tt.want[i] {
+					t.Fatalf("element %d is %s, expected %s", i, e.Text(16), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeBytes253Chunks(t *testing.T) {
+	msg := testBytes(100) // 800 бітів - 4 блоки по 253 біти
+
+	for _, order := range []ByteOrder{BigEndian, LittleEndian} {
+		elems, err := encodeBytes(msg, HashBytesOptions{ChunkBits: CHUNK253, ByteOrder: order})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(elems) != 4 {
+			t.Fatalf("expected 4 elements, got %d", len(elems))
+		}
+
+		for _, e := range elems {
+			if e.BitLen() > CHUNK253 || !inField(e) {
+				t.Fatalf("element %s does not fit into 253 bits", e)
+			}
+		}
+	}
+}
+
+func TestHashBytesOptionsZeroSuffix(t *testing.T) {
+	a := []byte("abc")
+	b := []byte("abc\x00")
+
+	if HashBytes(a).Cmp(HashBytes(b)) != 0 {
+		t.Fatalf("legacy encoding is expected to ignore trailing zero bytes")
+	}
+
+	for _, padding := range []Padding{PaddingLengthPrefix, Padding10} {
+		for _, chunkBits := range []int{CHUNK248, CHUNK253} {
+			opts := HashBytesOptions{Padding: padding, ChunkBits: chunkBits}
+
+			ha, _ := HashBytesWithOptions(a, opts)
+			hb, _ := HashBytesWithOptions(b, opts)
+			if ha.Cmp(hb) == 0 {
+				t.Fatalf("padding %d, chunk %d: \"abc\" and \"abc\\x00\" collide", padding, chunkBits)
+			}
+		}
+	}
+}
+
+// TestPadding10Collision - без тегу домену доповнення 10* дає колізію: m2 = геш першого кадру m1 || хвіст m1
+func TestPadding10Collision(t *testing.T) {
+	opts := HashBytesOptions{Padding: Padding10}
+
+	var m1, m2 []byte
+	for seed := byte(0); m2 == nil; seed++ { // шукаємо m1, геш першого кадру якого вміщується в блок з 31 байта
+		m1 = bytes.Repeat([]byte{seed}, INPUTS*SBLOCK+10)
+
+		elems, _ := encodeBytes(m1, opts)
+		if h := Hash(elems[1 : INPUTS+1]); h.BitLen() <= CHUNK248 {
+			m2 = append(h.FillBytes(make([]byte, SBLOCK)), m1[INPUTS*SBLOCK:]...)
+		}
+	}
+
+	e1, _ := encodeBytes(m1, opts)
+	e2, _ := encodeBytes(m2, opts)
+	if absorbElements(e1[1:]).Cmp(absorbElements(e2[1:])) != 0 {
+		t.Fatalf("untagged 10* encodings of the %d- and %d-byte messages are expected to collide", len(m1), len(m2))
+	}
+
+	h1, _ := HashBytesWithOptions(m1, opts)
+	h2, _ := HashBytesWithOptions(m2, opts)
+	if h1.Cmp(h2) == 0 {
+		t.Fatalf("Padding10: %d- and %d-byte messages collide", len(m1), len(m2))
+	}
+}
+
+func TestHashBytesOptionsInvalid(t *testing.T) {
+	for _, opts := range []HashBytesOptions{
+		{ChunkBits: 254},
+		{Padding: Padding(7)},
+		{ByteOrder: ByteOrder(2)},
+	} {
+		if _, err := HashBytesWithOptions([]byte("abc"), opts); err != ErrInvalidOptions {
+			t.Fatalf("options %+v: expected ErrInvalidOptions, got %v", opts, err)
+		}
+	}
+}
+
+func zeros(n int) string {
+	s := ""
+	for i := 0; i < n; i++ {
+		s += "00"
+	}
+	return s
+}
+
+func TestHashBytesLegacyCollisions(t *testing.T) {
+	block := testBytes(SBLOCK)
+	frame := testBytes(SBLOCK * INPUTS)
+	suffix := testBytes(40)
+
+	collisions := []struct {
+		name string
+		a, b []byte
+	}{
+		{name: "trailing zero byte", a: []byte("abc"), b: []byte("abc\x00")},
+		{name: "trailing zero block", a: block, b: append(append([]byte{}, block...), make([]byte, SBLOCK)...)},
+		{name: "empty and zero block", a: nil, b: make([]byte, SBLOCK)},
+	}
+
+	for _, tt := range collisions {
+		t.Run(tt.name, func(t *testing.T) {
+			if HashBytes(tt.a).Cmp(HashBytes(tt.b)) != 0 {
+				t.Fatalf("legacy HashBytes is expected to collide")
+			}
+
+			if HashBytesStrict(tt.a).Cmp(HashBytesStrict(tt.b)) == 0 {
+				t.Fatalf("HashBytesStrict collides")
+			}
+		})
+	}
+
+	// продовження гешу: HashBytes(frame||suffix) обчислюється з HashBytes(frame) без знання frame
+	extended := append(append([]byte{}, frame...), suffix...)
+	forged := absorbElements(append([]*big.Int{HashBytes(frame)}, encodeChunks(suffix, len(suffix)*8, CHUNK248, BigEndian)...))
+
+	if HashBytes(extended).Cmp(forged) != 0 {
+		t.Fatalf("legacy HashBytes is expected to allow length extension")
+	}
+
+	strictForged := absorbElements(append([]*big.Int{HashBytesStrict(frame)}, encodeChunks(suffix, len(suffix)*8, CHUNK248, BigEndian)...))
+	if HashBytesStrict(extended).Cmp(strictForged) == 0 {
+		t.Fatalf("HashBytesStrict allows length extension")
+	}
+}
+
+func TestHashBytesStrictDistinct(t *testing.T) {
+	seen := map[string]int{}
+
+	// усі повідомлення з нулів довжиною 0..600 (через межі блоків і кадрів) мають різні геші
+	for n := 0; n <= 600; n += 1 + n/50 {
+		hash := HashBytesStrict(make([]byte, n)).String()
+		if m, ok := seen[hash]; ok {
+			t.Fatalf("zero messages of length %d and %d collide", m, n)
+		}
+		seen[hash] = n
+	}
+
+	if HashBytesStrict([]byte("abc")).Cmp(HashBytes([]byte("abc"))) == 0 {
+		t.Fatalf("HashBytesStrict is not separated from HashBytes")
+	}
+
+	// legacy-прообраз, блоки якого - strict-кодування "abc" без тегу домену: [3, "abc" || 0x80 || 0...]
+	crafted := append(append(make([]byte, SBLOCK-1), 3), "abc\x80"...)
+	untagged := []*big.Int{big.NewInt(3), new(big.Int).SetBytes(append([]byte("abc\x80"), make([]byte, SBLOCK-4)...))}
+
+	if elems, _ := encodeBytes(crafted, HashBytesOptions{}); len(elems) != 2 || elems[0].Cmp(untagged[0]) != 0 || elems[1].Cmp(untagged[1]) != 0 {
+		t.Fatalf("crafted preimage is encoded as %v", elems)
+	}
+
+	if HashBytesStrict([]byte("abc")).Cmp(HashBytes(crafted)) == 0 {
+		t.Fatalf("HashBytesStrict(abc) collides with a crafted legacy preimage")
+	}
+
+	for _, opts := range []HashBytesOptions{{Padding: PaddingLengthPrefix}, {Padding: Padding10}, {ChunkBits: CHUNK253}} {
+		if hash, _ := HashBytesWithOptions(crafted, opts); hash.Cmp(HashBytesStrict([]byte("abc"))) == 0 {
+			t.Fatalf("HashBytesStrict(abc) collides with options %+v", opts)
+		}
+	}
+
+	if strict, _ := encodeBytes(nil, HashBytesOptions{Padding: PaddingStrict}); strict[0].Cmp(new(big.Int).Lsh(big.NewInt(1), 253)) != 0 {
+		t.Fatalf("strict encoding of an empty message starts with %s", strict[0])
+	}
+}
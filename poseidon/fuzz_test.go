@@ -0,0 +1,201 @@
+package poseidon
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+// fuzzElements - функція декодування входу фаззера в масив елементів: перший байт задає кількість елементів
+// (0..INPUTS+1, тобто з неприпустимими 0 і 17), далі для кожного елемента байт-селектор вибирає 0, q-1, q+k
+// (k - наступний байт), випадковий елемент поля (32 байти за модулем q) або довільне 256-бітове значення (часто >= q).
+// Якщо дані закінчилися, відсутні байти вважаються нулями.
+func fuzzElements(data []byte) []*big.Int {
+	next := func() byte {
+		if len(data) == 0 {
+			return 0
+		}
+
+		b := data[0]
+		data = data[1:]
+
+		return b
+	}
+
+	word := func() *big.Int {
+		var b [32]byte
+		data = data[copy(b[:], data):]
+
+		return new(big.Int).SetBytes(b[:])
+	}
+
+	input := make([]*big.Int, int(next())%(INPUTS+2))
+	for i := range input {
+		switch next() % 5 {
+		case 0:
+			input[i] = big.NewInt(0)
+		case 1:
+			input[i] = new(big.Int).Sub(q, big.NewInt(1))
+		case 2:
+			input[i] = new(big.Int).Add(q, big.NewInt(int64(next())))
+		case 3:
+			x := word()
+			input[i] = x.Mod(x, q)
+		default:
+			input[i] = word()
+		}
+	}
+
+	return input
+}
+
+// fuzzResult - результат однієї з функцій пакета, який порівнюється з результатом iden3
+type fuzzResult struct {
+	name string
+	got  *big.Int
+	err  error
+}
+
+// sameError - функція перевірки, що помилка пакета відповідає помилці iden3 (повідомлення пакета без префікса
+// "poseidon: " є частиною повідомлення iden3, наприклад "invalid inputs length" і "invalid inputs length 17, max 16")
+func sameError(err, libErr error) bool {
+	return err != nil && strings.Contains(libErr.Error(), strings.TrimPrefix(err.Error(), "poseidon: "))
+}
+
+// FuzzHash - диференційний фаззинг Hash і функцій з перевіркою входу (Hasher.Hash, HashElements, HashConstantTime)
+// проти poseidon.Hash з go-iden3-crypto: для коректних входів результати мають збігатися, для некоректних
+// (кількість елементів 0 або більше 16, елементи >= q) функції з перевіркою мають повертати відповідну помилку,
+// а Hash - поводитися, як описано в checkUncheckedHash
+func FuzzHash(f *testing.F) {
+	f.Add([]byte{1, 0})                  // [0]
+	f.Add([]byte{2, 1, 1})               // [q-1, q-1]
+	f.Add([]byte{1, 2, 0})               // [q]
+	f.Add([]byte{3, 0, 2, 255, 1})       // [0, q+255, q-1]
+	f.Add([]byte{0})                     // порожній вхід
+	f.Add([]byte{INPUTS + 1})            // 17 елементів
+	f.Add([]byte{INPUTS, 1, 1, 1, 1, 1}) // 16 елементів
+	f.Add(append([]byte{1, 4}, make([]byte, 32)...))
+	f.Add(append([]byte{2, 3}, []byte("fuzzing poseidon against iden3 library")...))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		input := fuzzElements(data)
+
+		snapshot := make([]*big.Int, len(input))
+		elems := make([]Element, len(input))
+		for i, x := range input {
+			snapshot[i] = new(big.Int).Set(x)
+			x.FillBytes(elems[i][:]) // без перевірки NewElement, щоб передати й значення >= q
+		}
+
+		libHash, libErr := poseidon.Hash(input)
+
+		hashed, err := NewHasher().Hash(nil, input)
+		digest, elemErr := HashElements(elems...)
+		ct, ctErr := HashConstantTime(elems...)
+
+		checked := []fuzzResult{
+			{"Hasher.Hash", hashed, err},
+			{"HashElements", digest.BigInt(), elemErr},
+			{"HashConstantTime", ct.BigInt(), ctErr},
+		}
+
+		if libErr != nil {
+			for _, c := range checked {
+				if !sameError(c.err, libErr) {
+					t.Fatalf("%d elements: %s returned %v; library returned %v", len(input), c.name, c.err, libErr)
+				}
+			}
+
+			checkUncheckedHash(t, input, libErr)
+			return
+		}
+
+		checked = append(checked, fuzzResult{"Hash", Hash(input), nil})
+
+		for _, c := range checked {
+			if c.err != nil || c.got.Cmp(libHash) != 0 {
+				t.Fatalf("%d elements: %s returned %v, %v; library returned %s", len(input), c.name, c.got, c.err, libHash)
+			}
+		}
+
+		for i := range input {
+			if input[i].Cmp(snapshot[i]) != 0 {
+				t.Fatalf("input %d was modified", i)
+			}
+		}
+	})
+}
+
+// checkUncheckedHash - перевірка Hash (без перевірки входу) на вході, який iden3 відхиляє: для 0 або більше 16
+// елементів Hash панікує, а елементи >= q зводяться за модулем q першим addRoundKeys, тому результат має
+// збігатися з iden3 для зведеного входу
+func checkUncheckedHash(t *testing.T, input []*big.Int, libErr error) {
+	t.Helper()
+
+	var got *big.Int
+	panicked := func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		got = Hash(input)
+		return false
+	}()
+
+	if len(input) == 0 || len(input) > INPUTS {
+		if !panicked {
+			t.Fatalf("%d elements: Hash returned %v without panic; library returned %v", len(input), got, libErr)
+		}
+		return
+	}
+
+	if panicked {
+		t.Fatalf("%d elements: Hash panicked; library returned %v", len(input), libErr)
+	}
+
+	reduced := make([]*big.Int, len(input))
+	for i, x := range input {
+		reduced[i] = new(big.Int).Mod(x, q)
+	}
+
+	if want, err := poseidon.Hash(reduced); err != nil || got.Cmp(want) != 0 {
+		t.Fatalf("%d elements: Hash returned %s; library returned %v, %v for the input reduced mod q", len(input), got, want, err)
+	}
+}
+
+// FuzzHashBytes - диференційний фаззинг HashBytes і потокового BytesHasher проти poseidon.HashBytes з go-iden3-crypto;
+// split задає межу, по якій повідомлення записується в BytesHasher двома частинами.
+// Для порожнього повідомлення iden3 повертає nil, а HashBytes - геш кадру з нулів (як absorbElements без елементів).
+func FuzzHashBytes(f *testing.F) {
+	f.Add([]byte{}, uint(0))
+	f.Add([]byte("abc"), uint(1))
+	f.Add([]byte("abc\x00"), uint(3))
+	f.Add(testBytes(SBLOCK), uint(30))
+	f.Add(testBytes(SBLOCK+1), uint(31))
+	f.Add(testBytes(SBLOCK*INPUTS), uint(100))
+	f.Add(testBytes(SBLOCK*INPUTS+1), uint(496))
+
+	f.Fuzz(func(t *testing.T, msg []byte, split uint) {
+		libHash, err := poseidon.HashBytes(msg)
+		if err != nil {
+			t.Fatalf("library returned %v", err)
+		}
+
+		if len(msg) == 0 {
+			libHash = absorbElements(nil)
+		}
+
+		if hash := HashBytes(msg); hash.Cmp(libHash) != 0 {
+			t.Fatalf("length %d: HashBytes is %s, library is %s", len(msg), hash, libHash)
+		}
+
+		split %= uint(len(msg) + 1)
+
+		h := NewBytesHasher()
+		h.Write(msg[:split])
+		h.Write(msg[split:])
+
+		if hash, err := h.Sum(); err != nil || hash.Cmp(libHash) != 0 {
+			t.Fatalf("length %d, split %d: BytesHasher returned %v, %v; library is %s", len(msg), split, hash, err, libHash)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package poseidon
+
+import "math/big"
+
+// grain - генератор Grain LFSR з референсної реалізації Poseidon (80 бітів стану), яким Neptune, arkworks і Halo2
+// генерують константи раундів і матриці
+type grain struct {
+	state [80]bool
+	pos   int
+}
+
+// newGrain - функція ініціалізації Grain LFSR для простого поля розміром nbits бітів: стан - біти
+// (1 [2], sbox [4], nbits [12], t [12], rf [10], rp [10], 2^30 - 1 [30]), перші 160 бітів відкидаються.
+// Референсний скрипт Poseidon (і arkworks, Halo2) записує sbox = 0 для x^alpha, Neptune - sbox = 1.
+func newGrain(sbox, nbits, t, rf, rp int) *grain {
+	g := &grain{}
+	i := 0
+
+	for _, f := range [][2]int{{1, 2}, {sbox, 4}, {nbits, 12}, {t, 12}, {rf, 10}, {rp, 10}, {1<<30 - 1, 30}} {
+		for b := f[1] - 1; b >= 0; b-- {
+			g.state[i] = f[0]>>b&1 == 1
+			i++
+		}
+	}
+
+	for i := 0; i < 160; i++ {
+		g.next()
+	}
+
+	return g
+}
+
+// next - функція зсуву регістра: новий біт b[62] ^ b[51] ^ b[38] ^ b[23] ^ b[13] ^ b[0]
+func (g *grain) next() bool {
+	bit := func(i int) bool { return g.state[(g.pos+i)%80] }
+
+	b := bit(62) != bit(51) != bit(38) != bit(23) != bit(13) != bit(0)
+	g.state[g.pos] = b
+	g.pos = (g.pos + 1) % 80
+
+	return b
+}
+
+// bit - функція наступного біта з фільтром: біти беруться парами, другий біт видається, якщо перший дорівнює 1
+func (g *grain) bit() bool {
+	for !g.next() {
+		g.next()
+	}
+
+	return g.next()
+}
+
+// bits - функція наступних nbits бітів як числа (старший біт перший)
+func (g *grain) bits(nbits int) *big.Int {
+	x := new(big.Int)
+	for i := 0; i < nbits; i++ {
+		x.Lsh(x, 1)
+		if g.bit() {
+			x.SetBit(x, 0, 1)
+		}
+	}
+
+	return x
+}
+
+// field - функція наступного елемента поля за модулем p з відкиданням значень, не менших за p (константи раундів)
+func (g *grain) field(p *big.Int, nbits int) *big.Int {
+	for {
+		if x := g.bits(nbits); x.Cmp(p) < 0 {
+			return x
+		}
+	}
+}
+
+// fieldModP - функція наступного елемента поля як nbits бітів за модулем p (без відкидання; матриці arkworks і Halo2)
+func (g *grain) fieldModP(p *big.Int, nbits int) *big.Int {
+	x := g.bits(nbits)
+	return x.Mod(x, p)
+}
+
+// cauchyMatrix - функція матриці Коші m[i][j] = 1 / (xs[i] + ys[j]) mod p
+func cauchyMatrix(xs, ys []*big.Int, p *big.Int) [][]*big.Int {
+	m := make([][]*big.Int, len(xs))
+	for i := range m {
+		m[i] = make([]*big.Int, len(ys))
+		for j := range m[i] {
+			sum := new(big.Int).Add(xs[i], ys[j])
+			m[i][j] = sum.ModInverse(sum.Mod(sum, p), p)
+		}
+	}
+
+	return m
+}
+
+// fieldPermutation - параметри перестановки Poseidon без оптимізацій над довільним простим полем
+// (Neptune, arkworks, Halo2): nRoundsF/2 повних раундів, nRoundsP часткових і nRoundsF/2 повних
+type fieldPermutation struct {
+	p        *big.Int
+	alpha    *big.Int // степінь S-блоку x^alpha
+	nRoundsF int
+	nRoundsP int
+	c        []*big.Int   // (nRoundsF + nRoundsP) * t констант раундів, по t на раунд
+	m        [][]*big.Int // матриця MDS: новий state[i] = sum_j m[i][j] * state[j]
+}
+
+// permute - перестановка: кожен раунд додає t констант, застосовує x^alpha до всього стану (повні раунди)
+// або до state[0] (часткові) і множить стан на матрицю m, як addRoundKeys, exp5state і mix у permute.
+// Елементи state змінюються на місці.
+func (fp *fieldPermutation) permute(state []*big.Int) []*big.Int {
+	t := len(state)
+	next := make([]*big.Int, t)
+	mul := new(big.Int)
+
+	for r := 0; r < fp.nRoundsF+fp.nRoundsP; r++ {
+		for i := range state {
+			state[i].Add(state[i], fp.c[r*t+i]).Mod(state[i], fp.p)
+		}
+
+		full := r < fp.nRoundsF/2 || r >= fp.nRoundsF/2+fp.nRoundsP
+		for i := range state {
+			if full || i == 0 {
+				state[i].Exp(state[i], fp.alpha, fp.p)
+			}
+		}
+
+		for i := range next {
+			next[i] = new(big.Int)
+			for j := range state {
+				next[i].Add(next[i], mul.Mul(fp.m[i][j], state[j]))
+			}
+			next[i].Mod(next[i], fp.p)
+		}
+		state, next = next, state
+	}
+
+	return state
+}
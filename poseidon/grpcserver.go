@@ -0,0 +1,262 @@
+//go:build !(js && wasm)
+
+package poseidon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"poseidonAlgorithm/poseidonpb"
+)
+
+const defaultMaxLeaves = 1 << 16 // максимальна кількість листя в MerkleRoot і MerkleProof
+
+// grpcServer - реалізація PoseidonService (proto/poseidon/v1/poseidon.proto) поверх Hash, HashBytes і MerkleRoot
+type grpcServer struct {
+	poseidonpb.UnimplementedPoseidonServiceServer
+
+	maxBatch  int // максимальна кількість елементів в BatchHash
+	maxLeaves int // максимальна кількість листя в запитах дерева Меркла
+}
+
+func newGRPCServer(maxBatch, maxLeaves int) *grpcServer {
+	return &grpcServer{maxBatch: maxBatch, maxLeaves: maxLeaves}
+}
+
+// register - функція створення gRPC-сервера з зареєстрованим PoseidonService
+func (s *grpcServer) register(opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(opts...)
+	poseidonpb.RegisterPoseidonServiceServer(srv, s)
+
+	return srv
+}
+
+func (s *grpcServer) Hash(_ context.Context, req *poseidonpb.HashRequest) (*poseidonpb.HashResponse, error) {
+	hash, err := hashProtoElements(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &poseidonpb.HashResponse{Hash: encodeDigest(hash)}, nil
+}
+
+func (s *grpcServer) HashBytes(_ context.Context, req *poseidonpb.HashBytesRequest) (*poseidonpb.HashResponse, error) {
+	return &poseidonpb.HashResponse{Hash: encodeDigest(hashProtoBytes(req))}, nil
+}
+
+// HashStream - функція потокового гешування: частини повідомлення поглинаються по мірі надходження,
+// тому пам'ять сервера не залежить від довжини повідомлення
+func (s *grpcServer) HashStream(stream poseidonpb.PoseidonService_HashStreamServer) error {
+	var h *BytesHasher
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if h == nil { // параметри гешування беруться з першого повідомлення
+			if req.GetStrict() {
+				h = NewStrictBytesHasher(req.GetLength())
+			} else {
+				h = NewBytesHasher()
+			}
+		}
+
+		if _, err := h.Write(req.GetChunk()); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if h == nil { // порожній потік - геш порожнього повідомлення
+		h = NewBytesHasher()
+	}
+
+	hash, err := h.Sum()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return stream.SendAndClose(&poseidonpb.HashResponse{Hash: encodeDigest(hash)})
+}
+
+// BatchHash - функція гешування кількох незалежних запитів; помилка в одному запиті повертається в його результаті
+func (s *grpcServer) BatchHash(_ context.Context, req *poseidonpb.BatchHashRequest) (*poseidonpb.BatchHashResponse, error) {
+	if len(req.GetItems()) > s.maxBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "batch is limited to %d items", s.maxBatch)
+	}
+
+	results := make([]*poseidonpb.BatchResult, len(req.GetItems()))
+
+	for i, item := range req.GetItems() {
+		var hash *big.Int
+		var err error
+
+		switch input := item.GetInput().(type) {
+		case *poseidonpb.BatchItem_Elements:
+			hash, err = hashProtoElements(input.Elements)
+		case *poseidonpb.BatchItem_Bytes:
+			hash = hashProtoBytes(input.Bytes)
+		default:
+			err = errors.New("item has no input")
+		}
+
+		if err != nil {
+			results[i] = &poseidonpb.BatchResult{Result: &poseidonpb.BatchResult_Error{Error: err.Error()}}
+		} else {
+			results[i] = &poseidonpb.BatchResult{Result: &poseidonpb.BatchResult_Hash{Hash: encodeDigest(hash)}}
+		}
+	}
+
+	return &poseidonpb.BatchHashResponse{Results: results}, nil
+}
+
+func (s *grpcServer) MerkleRoot(_ context.Context, req *poseidonpb.MerkleRootRequest) (*poseidonpb.HashResponse, error) {
+	leaves, err := s.decodeLeaves(req.GetLeaves())
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := MerkleRoot(leaves, int(req.GetArity()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &poseidonpb.HashResponse{Hash: encodeDigest(root)}, nil
+}
+
+func (s *grpcServer) MerkleProof(_ context.Context, req *poseidonpb.MerkleProofRequest) (*poseidonpb.MerkleProofResponse, error) {
+	leaves, err := s.decodeLeaves(req.GetLeaves())
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := MerkleProof(leaves, int(req.GetArity()), int(req.GetIndex()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	root, _ := MerkleRoot(leaves, int(req.GetArity())) // листя і арність вже перевірені в MerkleProof
+
+	resp := &poseidonpb.MerkleProofResponse{Root: encodeDigest(root)}
+
+	for _, step := range proof {
+		siblings := make([][]byte, len(step.Siblings))
+		for i, x := range step.Siblings {
+			siblings[i] = encodeDigest(x)
+		}
+
+		resp.Steps = append(resp.Steps, &poseidonpb.MerkleProofStep{Index: uint32(step.Index), Siblings: siblings})
+	}
+
+	return resp, nil
+}
+
+// decodeLeaves - функція розбору листя дерева Меркла з урахуванням обмеження maxLeaves
+func (s *grpcServer) decodeLeaves(raw [][]byte) ([]*big.Int, error) {
+	if len(raw) > s.maxLeaves {
+		return nil, status.Errorf(codes.InvalidArgument, "merkle tree is limited to %d leaves", s.maxLeaves)
+	}
+
+	leaves := make([]*big.Int, len(raw))
+
+	for i, b := range raw {
+		x, err := decodeElement(b)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "leaf %d: %v", i, err)
+		}
+		leaves[i] = x
+	}
+
+	return leaves, nil
+}
+
+// hashProtoElements - функція гешування елементів поля з gRPC-запиту
+func hashProtoElements(req *poseidonpb.HashRequest) (*big.Int, error) {
+	if n := len(req.GetElements()); n == 0 || n > INPUTS {
+		return nil, fmt.Errorf("expected 1..%d elements, got %d", INPUTS, n)
+	}
+
+	input := make([]*big.Int, len(req.GetElements()))
+
+	for i, b := range req.GetElements() {
+		x, err := decodeElement(b)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		input[i] = x
+	}
+
+	return Hash(input), nil
+}
+
+// hashProtoBytes - функція гешування масиву байтів з gRPC-запиту
+func hashProtoBytes(req *poseidonpb.HashBytesRequest) *big.Int {
+	if req.GetStrict() {
+		return HashBytesStrict(req.GetData())
+	}
+
+	return HashBytes(req.GetData())
+}
+
+// decodeElement - функція розбору елемента поля з big-endian масиву не довше 32 байтів
+func decodeElement(b []byte) (*big.Int, error) {
+	if len(b) > 32 {
+		return nil, errors.New("element is longer than 32 bytes")
+	}
+
+	x := new(big.Int).SetBytes(b)
+	if !inField(x) {
+		return nil, ErrInvalidInput
+	}
+
+	return x, nil
+}
+
+// encodeDigest - функція кодування гешу в 32-байтовий big-endian масив
+func encodeDigest(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, 32))
+}
+
+// serveGRPC - функція обслуговування gRPC-запитів на listener до скасування ctx;
+// після скасування сервер чекає завершення активних викликів не довше shutdownTimeout, а потім закриває їх примусово
+func serveGRPC(ctx context.Context, ln net.Listener, srv *grpc.Server, shutdownTimeout time.Duration) error {
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		srv.Stop()
+	}
+
+	if err := <-errCh; !errors.Is(err, grpc.ErrServerStopped) { // сервер міг зупинитися ще до початку Serve
+		return err
+	}
+
+	return nil
+}
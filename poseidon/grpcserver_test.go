@@ -0,0 +1,254 @@
+//go:build !(js && wasm)
+
+package poseidon
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"poseidonAlgorithm/poseidonpb"
+)
+
+// newTestGRPCClient - функція запуску gRPC-сервера в пам'яті (bufconn) і створення клієнта до нього
+func newTestGRPCClient(t *testing.T, maxBatch int) poseidonpb.PoseidonServiceClient {
+	ln := bufconn.Listen(1 << 20)
+	srv := newGRPCServer(maxBatch, 64).register()
+
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return poseidonpb.NewPoseidonServiceClient(conn)
+}
+
+func expectCode(t *testing.T, name string, err error, code codes.Code) {
+	t.Helper()
+
+	if status.Code(err) != code {
+		t.Fatalf("%s: expected %v, got %v", name, code, err)
+	}
+}
+
+func TestGRPCHash(t *testing.T) {
+	client := newTestGRPCClient(t, defaultMaxBatch)
+	ctx := context.Background()
+
+	resp, err := client.Hash(ctx, &poseidonpb.HashRequest{Elements: [][]byte{{1}, {0, 2}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Hash([]*big.Int{big.NewInt(1), big.NewInt(2)})
+	if !bytes.Equal(resp.GetHash(), encodeDigest(want)) {
+		t.Fatalf("hash is %x, expected %x", resp.GetHash(), encodeDigest(want))
+	}
+
+	for name, req := range map[string]*poseidonpb.HashRequest{
+		"empty":        {},
+		"too many":     {Elements: make([][]byte, INPUTS+1)},
+		"not in field": {Elements: [][]byte{q.Bytes()}},
+		"too long":     {Elements: [][]byte{make([]byte, 33)}},
+	} {
+		_, err := client.Hash(ctx, req)
+		expectCode(t, name, err, codes.InvalidArgument)
+	}
+}
+
+func TestGRPCHashBytes(t *testing.T) {
+	client := newTestGRPCClient(t, defaultMaxBatch)
+	ctx := context.Background()
+
+	msg := testBytes(100)
+
+	resp, err := client.HashBytes(ctx, &poseidonpb.HashBytesRequest{Data: msg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(resp.GetHash(), encodeDigest(HashBytes(msg))) {
+		t.Fatalf("hash does not match HashBytes")
+	}
+
+	resp, err = client.HashBytes(ctx, &poseidonpb.HashBytesRequest{Data: msg, Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(resp.GetHash(), encodeDigest(HashBytesStrict(msg))) {
+		t.Fatalf("strict hash does not match HashBytesStrict")
+	}
+}
+
+func TestGRPCHashStream(t *testing.T) {
+	client := newTestGRPCClient(t, defaultMaxBatch)
+	ctx := context.Background()
+
+	msg := testBytes(1000)
+
+	for _, strict := range []bool{false, true} {
+		stream, err := client.HashStream(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < len(msg); i += 77 {
+			req := &poseidonpb.HashStreamRequest{Chunk: msg[i:minInt(len(msg), i+77)]}
+			if i == 0 {
+				req.Strict, req.Length = strict, uint64(len(msg))
+			}
+
+			if err := stream.Send(req); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		resp, err := stream.CloseAndRecv()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := HashBytes(msg)
+		if strict {
+			want = HashBytesStrict(msg)
+		}
+
+		if !bytes.Equal(resp.GetHash(), encodeDigest(want)) {
+			t.Fatalf("strict=%v: streamed hash does not match", strict)
+		}
+	}
+
+	stream, _ := client.HashStream(ctx) // порожній потік - геш порожнього повідомлення
+	resp, err := stream.CloseAndRecv()
+	if err != nil || !bytes.Equal(resp.GetHash(), encodeDigest(HashBytes(nil))) {
+		t.Fatalf("empty stream returned %x, %v", resp.GetHash(), err)
+	}
+
+	stream, _ = client.HashStream(ctx)
+	stream.Send(&poseidonpb.HashStreamRequest{Chunk: msg, Strict: true, Length: 10})
+	_, err = stream.CloseAndRecv()
+	expectCode(t, "strict stream longer than declared", err, codes.InvalidArgument)
+
+	stream, _ = client.HashStream(ctx)
+	stream.Send(&poseidonpb.HashStreamRequest{Chunk: msg[:5], Strict: true, Length: 10})
+	_, err = stream.CloseAndRecv()
+	expectCode(t, "strict stream shorter than declared", err, codes.InvalidArgument)
+}
+
+func TestGRPCBatchHash(t *testing.T) {
+	client := newTestGRPCClient(t, 3)
+	ctx := context.Background()
+
+	resp, err := client.BatchHash(ctx, &poseidonpb.BatchHashRequest{Items: []*poseidonpb.BatchItem{
+		{Input: &poseidonpb.BatchItem_Elements{Elements: &poseidonpb.HashRequest{Elements: [][]byte{{1}, {2}}}}},
+		{Input: &poseidonpb.BatchItem_Bytes{Bytes: &poseidonpb.HashBytesRequest{Data: []byte("abc")}}},
+		{Input: &poseidonpb.BatchItem_Elements{Elements: &poseidonpb.HashRequest{}}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := resp.GetResults()
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	if want := Hash([]*big.Int{big.NewInt(1), big.NewInt(2)}); !bytes.Equal(results[0].GetHash(), encodeDigest(want)) {
+		t.Fatalf("first result is %v", results[0])
+	}
+
+	if !bytes.Equal(results[1].GetHash(), encodeDigest(HashBytes([]byte("abc")))) {
+		t.Fatalf("second result is %v", results[1])
+	}
+
+	if results[2].GetError() == "" || results[2].GetHash() != nil {
+		t.Fatalf("invalid item returned %v", results[2])
+	}
+
+	_, err = client.BatchHash(ctx, &poseidonpb.BatchHashRequest{Items: make([]*poseidonpb.BatchItem, 4)})
+	expectCode(t, "oversized batch", err, codes.InvalidArgument)
+}
+
+func TestGRPCMerkle(t *testing.T) {
+	client := newTestGRPCClient(t, defaultMaxBatch)
+	ctx := context.Background()
+
+	leaves := make([]*big.Int, 10)
+	raw := make([][]byte, len(leaves))
+	for i := range leaves {
+		leaves[i] = big.NewInt(int64(i + 1))
+		raw[i] = leaves[i].Bytes()
+	}
+
+	want, _ := MerkleRoot(leaves, 4)
+
+	resp, err := client.MerkleRoot(ctx, &poseidonpb.MerkleRootRequest{Leaves: raw, Arity: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(resp.GetHash(), encodeDigest(want)) {
+		t.Fatalf("root does not match MerkleRoot")
+	}
+
+	proofResp, err := client.MerkleProof(ctx, &poseidonpb.MerkleProofRequest{Leaves: raw, Arity: 4, Index: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof := make([]MerkleProofStep, len(proofResp.GetSteps()))
+	for i, step := range proofResp.GetSteps() {
+		proof[i].Index = int(step.GetIndex())
+		for _, s := range step.GetSiblings() {
+			proof[i].Siblings = append(proof[i].Siblings, new(big.Int).SetBytes(s))
+		}
+	}
+
+	root := new(big.Int).SetBytes(proofResp.GetRoot())
+	if err := VerifyMerkleProof(leaves[7], proof, root, 4, len(leaves)); err != nil || root.Cmp(want) != 0 {
+		t.Fatalf("proof from the service does not verify: %v", err)
+	}
+
+	_, err = client.MerkleRoot(ctx, &poseidonpb.MerkleRootRequest{Leaves: raw, Arity: 1})
+	expectCode(t, "invalid arity", err, codes.InvalidArgument)
+
+	_, err = client.MerkleRoot(ctx, &poseidonpb.MerkleRootRequest{Leaves: make([][]byte, 65), Arity: 2})
+	expectCode(t, "too many leaves", err, codes.InvalidArgument)
+
+	_, err = client.MerkleProof(ctx, &poseidonpb.MerkleProofRequest{Leaves: raw, Arity: 2, Index: 10})
+	expectCode(t, "leaf index out of range", err, codes.InvalidArgument)
+}
+
+func TestServeGRPCGracefulShutdown(t *testing.T) {
+	ln := bufconn.Listen(1 << 20)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() { done <- serveGRPC(ctx, ln, newGRPCServer(defaultMaxBatch, 64).register(), time.Second) }()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("serveGRPC returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("gRPC server did not shut down")
+	}
+}
@@ -0,0 +1,75 @@
+package poseidon
+
+import "math/big"
+
+// Hasher - гешер з власним буфером стану для повторних викликів без виділення пам'яті в купі:
+// стан і проміжні значення зберігаються в полях Hasher, а арифметика виконується над лімами фіксованої довжини.
+// Результати збігаються з Hash. Hasher не можна використовувати з кількох горутин одночасно
+// (для паралельного гешування потрібен окремий Hasher на горутину); нульове значення готове до використання.
+type Hasher struct {
+	state   [INPUTS + 1]fe
+	scratch [INPUTS + 1]fe
+	buf     [32]byte
+}
+
+// NewHasher - функція створення гешера
+func NewHasher() *Hasher {
+	return &Hasher{}
+}
+
+// Hash - функція гешування 1..16 елементів поля; результат записується в dst (якщо dst дорівнює nil, створюється новий
+// *big.Int) і повертається. Якщо dst вже має достатню ємність, виклик не виділяє пам'яті.
+// Повертає ErrInputsLength або ErrInvalidInput для некоректного входу.
+func (h *Hasher) Hash(dst *big.Int, input []*big.Int) (*big.Int, error) {
+	if len(input) == 0 || len(input) > INPUTS {
+		return nil, ErrInputsLength
+	}
+
+	state := h.state[:len(input)+1]
+	state[0] = fe{}
+
+	for i, x := range input {
+		if !inField(x) {
+			return nil, ErrInvalidInput
+		}
+
+		x.FillBytes(h.buf[:])
+		v := feFromBytes(&h.buf)
+		feToMont(&state[i+1], &v)
+	}
+
+	permuteCT(state, h.scratch[:len(state)])
+	feBytes(&h.buf, &state[0])
+
+	if dst == nil {
+		dst = new(big.Int)
+	}
+
+	return dst.SetBytes(h.buf[:]), nil
+}
+
+// HashElements - функція гешування 1..16 елементів поля типу Element без виділення пам'яті
+func (h *Hasher) HashElements(inputs ...Element) (Digest, error) {
+	var digest Digest
+
+	if len(inputs) == 0 || len(inputs) > INPUTS {
+		return digest, ErrInputsLength
+	}
+
+	state := h.state[:len(inputs)+1]
+	state[0] = fe{}
+
+	for i := range inputs {
+		v := feFromBytes((*[32]byte)(&inputs[i]))
+		if feLessThanQ(&v) == 0 {
+			return digest, ErrInvalidInput
+		}
+
+		feToMont(&state[i+1], &v)
+	}
+
+	permuteCT(state, h.scratch[:len(state)])
+	feBytes((*[32]byte)(&digest), &state[0])
+
+	return digest, nil
+}
@@ -0,0 +1,71 @@
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestHasherMatchesHash(t *testing.T) {
+	rnd := rand.New(rand.NewSource(4))
+	h := NewHasher()
+	dst := new(big.Int)
+
+	for n := 1; n <= INPUTS; n++ {
+		input := make([]*big.Int, n)
+		elems := make([]Element, n)
+		for i := range input {
+			elems[i] = randomElement(rnd)
+			input[i] = elems[i].BigInt()
+		}
+
+		want := Hash(input)
+
+		got, err := h.Hash(dst, input)
+		if err != nil || got != dst || got.Cmp(want) != 0 {
+			t.Fatalf("width %d: Hasher.Hash returned %s, %v; expected %s", n+1, got, err, want)
+		}
+
+		digest, err := h.HashElements(elems...)
+		if err != nil || digest.BigInt().Cmp(want) != 0 {
+			t.Fatalf("width %d: Hasher.HashElements returned %s, %v; expected %s", n+1, digest, err, want)
+		}
+	}
+
+	if got, _ := new(Hasher).Hash(nil, []*big.Int{big.NewInt(1)}); got.Cmp(Hash([]*big.Int{big.NewInt(1)})) != 0 {
+		t.Fatalf("zero Hasher with nil dst returned %s", got)
+	}
+
+	if _, err := h.Hash(dst, []*big.Int{big.NewInt(1), q}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("element q returned %v", err)
+	}
+
+	if _, err := h.Hash(dst, nil); !errors.Is(err, ErrInputsLength) {
+		t.Fatalf("empty input returned %v", err)
+	}
+}
+
+func TestHasherZeroAllocs(t *testing.T) {
+	h := NewHasher()
+	dst := new(big.Int)
+
+	for _, n := range []int{1, 2, 5, INPUTS} {
+		input := make([]*big.Int, n)
+		elems := make([]Element, n)
+		for i := range input {
+			input[i] = new(big.Int).Sub(q, big.NewInt(int64(i+1)))
+			elems[i], _ = NewElement(input[i])
+		}
+
+		h.Hash(dst, input) // перший виклик виділяє буфер dst
+
+		if allocs := testing.AllocsPerRun(100, func() { h.Hash(dst, input) }); allocs != 0 {
+			t.Fatalf("width %d: Hasher.Hash performs %v allocations per call", n+1, allocs)
+		}
+
+		if allocs := testing.AllocsPerRun(100, func() { h.HashElements(elems...) }); allocs != 0 {
+			t.Fatalf("width %d: Hasher.HashElements performs %v allocations per call", n+1, allocs)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package poseidon
+
+import (
+	"math/big"
+	"sync"
+)
+
+// fifthRootExp - показник оберненого S-блоку d = 5^-1 mod (q-1): (x^5)^d = x для будь-якого x, бо gcd(5, q-1) = 1
+var fifthRootExp = new(big.Int).ModInverse(big5int, new(big.Int).Sub(q, big.NewInt(1)))
+
+// inverseConsts - константи оберненої перестановки для однієї ширини стану
+type inverseConsts struct {
+	m   [][]*big.Int // обернена матриця M
+	p   [][]*big.Int // обернена матриця P
+	den []*big.Int   // обернені знаменники часткових раундів (див. inversePermute)
+}
+
+var (
+	inverseOnce   sync.Once
+	inverseTables []inverseConsts // за індексом ширина-2, як c.c, c.s, c.m, c.p
+)
+
+// fifthRoot - функція обчислення кореня п'ятого степеня по модулю q на місці (x = x^(1/5) mod q); повертає x
+func fifthRoot(x *big.Int) *big.Int {
+	return x.Exp(x, fifthRootExp, q)
+}
+
+// invertMatrix - функція обернення квадратної матриці над полем методом Гаусса-Жордана
+func invertMatrix(m [][]*big.Int) [][]*big.Int {
+	n := len(m)
+
+	a := make([][]*big.Int, n) // розширена матриця [m | I]
+	for i := range a {
+		a[i] = make([]*big.Int, 2*n)
+		for j := 0; j < n; j++ {
+			a[i][j] = new(big.Int).Set(m[i][j])
+			a[i][n+j] = new(big.Int)
+		}
+		a[i][n+i].SetInt64(1)
+	}
+
+	mul := new(big.Int)
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for a[pivot][col].Sign() == 0 { // матриці MDS невироджені, тому ненульовий опорний елемент завжди існує
+			pivot++
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+
+		inv := new(big.Int).ModInverse(a[col][col], q)
+		for j := range a[col] {
+			a[col][j].Mul(a[col][j], inv).Mod(a[col][j], q)
+		}
+
+		for i := range a {
+			if i == col || a[i][col].Sign() == 0 {
+				continue
+			}
+
+			factor := new(big.Int).Set(a[i][col])
+			for j := range a[i] {
+				a[i][j].Sub(a[i][j], mul.Mul(factor, a[col][j])).Mod(a[i][j], q)
+			}
+		}
+	}
+
+	inv := make([][]*big.Int, n)
+	for i := range inv {
+		inv[i] = a[i][n:]
+	}
+
+	return inv
+}
+
+// inverseConstants - функція отримання констант оберненої перестановки (обчислюються один раз при першому виклику)
+func inverseConstants(width int) *inverseConsts {
+	inverseOnce.Do(func() {
+		inverseTables = make([]inverseConsts, len(c.c))
+
+		for i := range inverseTables {
+			t := i + 2
+			S := c.s[i]
+			mul := new(big.Int)
+
+			den := make([]*big.Int, NROUNDSP[i])
+			for r := range den { // row[0] - sum_k row[k]*row[t+k-1] для рядка S раунду r
+				row := S[(2*t-1)*r : (2*t-1)*(r+1)]
+
+				d := new(big.Int).Set(row[0])
+				for k := 1; k < t; k++ {
+					d.Sub(d, mul.Mul(row[k], row[t+k-1]))
+				}
+				den[r] = d.ModInverse(d.Mod(d, q), q)
+			}
+
+			inverseTables[i] = inverseConsts{m: invertMatrix(c.m[i]), p: invertMatrix(c.p[i]), den: den}
+		}
+	})
+
+	return &inverseTables[width-2]
+}
+
+// inversePermute - обернена до permute перестановка: раунди виконуються у зворотному порядку з константами у
+// зворотному порядку, mix обертається оберненою матрицею, exp5 - коренем п'ятого степеня. Частковий раунд permute
+// з розрідженою матрицею S обчислює a = state[0]^5 + C, новий state[0] = row[0]*a + sum_k row[k]*state[k] і
+// state[k] += a*row[t+k-1], тому a відновлюється як (state[0] - sum_k row[k]*state'[k]) / (row[0] - sum_k row[k]*row[t+k-1]).
+// Елементи state змінюються на місці, результатом є вихідний стан перестановки.
+func inversePermute(state []*big.Int) []*big.Int {
+	countElements := len(state)
+
+	nRoundsF := NROUNDSF
+	nRoundsP := NROUNDSP[countElements-2]
+
+	C := c.c[countElements-2]
+	S := c.s[countElements-2]
+	inv := inverseConstants(countElements)
+
+	subRoundKeys := func(r int) {
+		for i := range state {
+			state[i].Sub(state[i], C[r+i]).Mod(state[i], q)
+		}
+	}
+
+	rootState := func() {
+		for i := range state {
+			fifthRoot(state[i])
+		}
+	}
+
+	state = mix(state, countElements, inv.m)
+	rootState()
+
+	for i := nRoundsF/2 - 2; i >= 0; i-- {
+		state = mix(state, countElements, inv.m)
+		subRoundKeys((nRoundsF/2+1)*countElements + nRoundsP + i*countElements)
+		rootState()
+	}
+
+	mul := new(big.Int)
+
+	for i := nRoundsP - 1; i >= 0; i-- {
+		row := S[(countElements*2-1)*i : (countElements*2-1)*(i+1)]
+
+		a := new(big.Int).Set(state[0])
+		for k := 1; k < countElements; k++ {
+			a.Sub(a, mul.Mul(row[k], state[k]))
+		}
+		a.Mod(a, q).Mul(a, inv.den[i]).Mod(a, q)
+
+		for k := 1; k < countElements; k++ {
+			state[k].Sub(state[k], mul.Mul(a, row[countElements+k-1])).Mod(state[k], q)
+		}
+
+		state[0] = fifthRoot(a.Sub(a, C[(nRoundsF/2+1)*countElements+i]).Mod(a, q))
+	}
+
+	state = mix(state, countElements, inv.p)
+	subRoundKeys((nRoundsF / 2) * countElements)
+	rootState()
+
+	for i := nRoundsF/2 - 2; i >= 0; i-- {
+		state = mix(state, countElements, inv.m)
+		subRoundKeys((i + 1) * countElements)
+		rootState()
+	}
+
+	subRoundKeys(0)
+
+	return state
+}
+
+// InversePermute - функція оберненої перестановки Poseidon: InversePermute(Permute(x)) == x для стану будь-якої
+// ширини 2..17. Призначена для тестування й алгебраїчного аналізу (не для гешування). Вхідний масив не змінюється;
+// повертає ErrInputsLength для неправильної ширини і ErrInvalidInput, якщо якийсь елемент не в межах [0, q).
+func InversePermute(state []*big.Int) ([]*big.Int, error) {
+	s, err := copyState(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return inversePermute(s), nil
+}
@@ -0,0 +1,127 @@
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestInversePermute(t *testing.T) {
+	rnd := rand.New(rand.NewSource(45))
+
+	for width := 2; width <= INPUTS+1; width++ {
+		states := [][]*big.Int{make([]*big.Int, width), make([]*big.Int, width), make([]*big.Int, width)}
+		for i := 0; i < width; i++ {
+			states[0][i] = big.NewInt(0)
+			states[1][i] = new(big.Int).Sub(q, big.NewInt(int64(i+1)))
+			states[2][i] = new(big.Int).Rand(rnd, q)
+		}
+
+		for _, x := range states {
+			snapshot := fieldState(x).clone()
+
+			y, err := Permute(x)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			back, err := InversePermute(y)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			forward, err := Permute(back)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := range x {
+				if x[i].Cmp(snapshot[i]) != 0 {
+					t.Fatalf("width %d: Permute modified its input", width)
+				}
+				if back[i].Cmp(x[i]) != 0 {
+					t.Fatalf("width %d: InversePermute(Permute(x))[%d] = %s, expected %s", width, i, back[i], x[i])
+				}
+				if forward[i].Cmp(y[i]) != 0 {
+					t.Fatalf("width %d: Permute(InversePermute(y))[%d] = %s, expected %s", width, i, forward[i], y[i])
+				}
+			}
+		}
+
+		// Hash - перший елемент перестановки стану [0, input...]
+		input := states[2][1:]
+		y, _ := Permute(append([]*big.Int{big.NewInt(0)}, input...))
+		if want := Hash(input); y[0].Cmp(want) != 0 {
+			t.Fatalf("width %d: Permute(0, input)[0] = %s, Hash is %s", width, y[0], want)
+		}
+	}
+
+	for _, state := range [][]*big.Int{ints(1), make([]*big.Int, INPUTS+2)} {
+		if _, err := Permute(state); !errors.Is(err, ErrInputsLength) {
+			t.Fatalf("width %d: Permute returned %v", len(state), err)
+		}
+		if _, err := InversePermute(state); !errors.Is(err, ErrInputsLength) {
+			t.Fatalf("width %d: InversePermute returned %v", len(state), err)
+		}
+	}
+
+	if _, err := Permute([]*big.Int{big.NewInt(0), q}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("element q returned %v", err)
+	}
+	if _, err := InversePermute([]*big.Int{big.NewInt(0), q}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("element q returned %v", err)
+	}
+}
+
+func TestInverseComponents(t *testing.T) {
+	for _, x := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), new(big.Int).Sub(q, big.NewInt(1))} {
+		if got := fifthRoot(exp5(new(big.Int).Set(x))); got.Cmp(x) != 0 {
+			t.Fatalf("fifthRoot(%s^5) = %s", x, got)
+		}
+	}
+
+	for i := range c.m {
+		for _, m := range [][][]*big.Int{c.m[i], c.p[i]} {
+			inv := invertMatrix(m)
+
+			for r := range m { // m * inv = I
+				for col := range m {
+					sum := new(big.Int)
+					for k := range m {
+						sum.Add(sum, new(big.Int).Mul(m[r][k], inv[k][col]))
+					}
+
+					want := big.NewInt(0)
+					if r == col {
+						want.SetInt64(1)
+					}
+
+					if sum.Mod(sum, q).Cmp(want) != 0 {
+						t.Fatalf("width %d: (M * M^-1)[%d][%d] = %s", i+2, r, col, sum)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestInversePermuteProperty - inversePermute повертає випадковий стан кожної ширини 2..17 після permute
+func TestInversePermuteProperty(t *testing.T) {
+	property := func(s fieldState) bool {
+		back := inversePermute(permute(s.clone()))
+
+		for i := range s {
+			if back[i].Cmp(s[i]) != 0 {
+				return false
+			}
+		}
+
+		return canonical(back)
+	}
+
+	if err := quick.Check(property, quickConfig(40)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,146 @@
+package poseidon
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math/big"
+)
+
+// Константи розділення доменів для ключових конструкцій. Значення записується в елемент ємності state[0],
+// який у функції Hash завжди дорівнює нулю, тому виходи PRF, KDF і MAC не перетинаються ні між собою, ні з Hash.
+// Значення - це ASCII-коди назв конструкцій ("PRF", "KDF", "MAC").
+const (
+	DomainPRF uint64 = 0x505246
+	DomainKDF uint64 = 0x4b4446
+	DomainMAC uint64 = 0x4d4143
+)
+
+// MACBLOCK - кількість елементів повідомлення, які поглинаються однією перестановкою в MAC
+// (ширина стану 17 мінус домен, ключ і проміжний тег)
+const MACBLOCK = INPUTS - 2
+
+// MAXKEYS - найбільша кількість ключів, яку виводить один виклик DeriveKeys (обмежує пам'ять і час на виклик)
+const MAXKEYS = 1024
+
+var (
+	ErrInvalidKey   = errors.New("poseidon: key is not inside Finite Field")
+	ErrInvalidInput = errors.New("poseidon: inputs values not inside Finite Field")
+	ErrInputsLength = errors.New("poseidon: invalid inputs length")
+	ErrInvalidCount = errors.New("poseidon: number of derived keys must be between 1 and MAXKEYS")
+)
+
+// inField - функція перевірки, що x є канонічним елементом поля (0 <= x < q)
+func inField(x *big.Int) bool {
+	return x != nil && x.Sign() >= 0 && x.Cmp(q) < 0
+}
+
+// keyedPermute - функція, яка будує стан [domain, key, elems...] і повертає перший елемент стану після перестановки
+func keyedPermute(domain uint64, key *big.Int, elems ...*big.Int) *big.Int {
+	state := make([]*big.Int, len(elems)+2)
+	state[0] = new(big.Int).SetUint64(domain)
+	state[1] = new(big.Int).Set(key)
+
+	for i, x := range elems {
+		state[i+2] = new(big.Int).Set(x)
+	}
+
+	return permute(state)[0]
+}
+
+// PRF - псевдовипадкова функція на основі перестановки Poseidon: PRF(key, input) = Poseidon([DomainPRF, key, input...])[0].
+// Приймає від 1 до 15 елементів input. Стійкість спирається на припущення, що перестановка Poseidon нерозрізнима
+// від випадкової, а ключ key - рівномірно випадковий елемент поля, відомий лише власнику.
+func PRF(key *big.Int, input []*big.Int) (*big.Int, error) {
+	if !inField(key) {
+		return nil, ErrInvalidKey
+	}
+
+	if len(input) == 0 || len(input) > INPUTS-1 {
+		return nil, ErrInputsLength
+	}
+
+	for _, x := range input {
+		if !inField(x) {
+			return nil, ErrInvalidInput
+		}
+	}
+
+	return keyedPermute(DomainPRF, key, input...), nil
+}
+
+// DeriveKeys - функція виведення n ключів з головного ключа master та мітки label (KDF).
+// i-й ключ дорівнює Poseidon([DomainKDF, master, HashBytesStrict(label), i])[0]; кодування мітки ін'єктивне, тому різні
+// мітки (зокрема "a" і "a\x00") дають незалежні набори ключів.
+// Якщо master має низьку ентропію (наприклад, пароль), KDF не уповільнює перебір - такий ключ потрібно спочатку посилити.
+// Повертає ErrInvalidCount для n поза 1..MAXKEYS.
+func DeriveKeys(master *big.Int, label string, n int) ([]*big.Int, error) {
+	if !inField(master) {
+		return nil, ErrInvalidKey
+	}
+
+	if n <= 0 || n > MAXKEYS {
+		return nil, ErrInvalidCount
+	}
+
+	labelHash := HashBytesStrict([]byte(label))
+	keys := make([]*big.Int, n)
+
+	for i := range keys {
+		keys[i] = keyedPermute(DomainKDF, master, labelHash, big.NewInt(int64(i)))
+	}
+
+	return keys, nil
+}
+
+// MAC - код автентифікації повідомлення msg на ключі key.
+// Початковий тег t = Poseidon([DomainMAC, key, len(msg)])[0] прив'язує довжину повідомлення, далі повідомлення
+// поглинається блоками по MACBLOCK елементів: t = Poseidon([DomainMAC, key, t, блок...])[0], неповний блок доповнюється нулями.
+// Стійкість до підробки спирається на ті ж припущення, що й PRF; тег не приховує повідомлення.
+func MAC(key *big.Int, msg []*big.Int) (*big.Int, error) {
+	if !inField(key) {
+		return nil, ErrInvalidKey
+	}
+
+	for _, x := range msg {
+		if !inField(x) {
+			return nil, ErrInvalidInput
+		}
+	}
+
+	tag := keyedPermute(DomainMAC, key, big.NewInt(int64(len(msg))))
+
+	for i := 0; i < len(msg); i += MACBLOCK {
+		block := make([]*big.Int, MACBLOCK+1) // проміжний тег і блок повідомлення
+		block[0] = tag
+
+		for j := 0; j < MACBLOCK; j++ {
+			if i+j < len(msg) {
+				block[j+1] = msg[i+j]
+			} else {
+				block[j+1] = new(big.Int)
+			}
+		}
+
+		tag = keyedPermute(DomainMAC, key, block...)
+	}
+
+	return tag, nil
+}
+
+// VerifyMAC - функція перевірки тегу tag для повідомлення msg; порівняння тегів виконується за сталий час
+func VerifyMAC(key *big.Int, msg []*big.Int, tag *big.Int) bool {
+	if !inField(tag) {
+		return false
+	}
+
+	expected, err := MAC(key, msg)
+	if err != nil {
+		return false
+	}
+
+	var a, b [32]byte
+	expected.FillBytes(a[:])
+	tag.FillBytes(b[:])
+
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
@@ -0,0 +1,153 @@
+package poseidon
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestPRF(t *testing.T) {
+	key := big.NewInt(42)
+	input := []*big.Int{big.NewInt(1), big.NewInt(2)}
+
+	a, err := PRF(key, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := PRF(key, input)
+	if a.Cmp(b) != 0 {
+		t.Fatalf("PRF is not deterministic: %s != %s", a, b)
+	}
+
+	if input[0].Int64() != 1 || input[1].Int64() != 2 {
+		t.Fatalf("PRF modified its input: %s", input)
+	}
+
+	other, _ := PRF(big.NewInt(43), input)
+	if a.Cmp(other) == 0 {
+		t.Fatalf("PRF output does not depend on the key")
+	}
+
+	plain := Hash([]*big.Int{key, input[0], input[1]})
+	if a.Cmp(plain) == 0 {
+		t.Fatalf("PRF output collides with Hash of the same elements")
+	}
+
+	if _, err := PRF(q, input); err != ErrInvalidKey {
+		t.Fatalf("expected ErrInvalidKey, got %v", err)
+	}
+
+	if _, err := PRF(key, nil); err != ErrInputsLength {
+		t.Fatalf("expected ErrInputsLength, got %v", err)
+	}
+
+	if _, err := PRF(key, make([]*big.Int, INPUTS)); err != ErrInputsLength {
+		t.Fatalf("expected ErrInputsLength, got %v", err)
+	}
+
+	if _, err := PRF(key, []*big.Int{big.NewInt(-1)}); err != ErrInvalidInput {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestDeriveKeys(t *testing.T) {
+	master := big.NewInt(123456789)
+
+	keys, err := DeriveKeys(master, "viewing key", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(keys) != 4 {
+		t.Fatalf("expected 4 keys, got %d", len(keys))
+	}
+
+	seen := map[string]bool{}
+	for _, k := range keys {
+		if !inField(k) {
+			t.Fatalf("derived key %s is not a field element", k)
+		}
+		if seen[k.String()] {
+			t.Fatalf("derived key %s is repeated", k)
+		}
+		seen[k.String()] = true
+	}
+
+	more, _ := DeriveKeys(master, "viewing key", 6)
+	for i := range keys {
+		if keys[i].Cmp(more[i]) != 0 {
+			t.Fatalf("key %d depends on the number of derived keys", i)
+		}
+	}
+
+	spending, _ := DeriveKeys(master, "spending key", 1)
+	if spending[0].Cmp(keys[0]) == 0 {
+		t.Fatalf("different labels produced the same key")
+	}
+
+	// мітки, які відрізняються лише нульовими байтами в кінці, мають однаковий legacy-геш HashBytes
+	for _, label := range []string{"viewing key\x00", "viewing key\x00\x00", "viewing key" + strings.Repeat("\x00", SBLOCK)} {
+		other, _ := DeriveKeys(master, label, 1)
+		if other[0].Cmp(keys[0]) == 0 {
+			t.Fatalf("labels %q and %q produced the same key", "viewing key", label)
+		}
+	}
+
+	for _, n := range []int{0, -1, MAXKEYS + 1, math.MaxInt} {
+		if _, err := DeriveKeys(master, "viewing key", n); err != ErrInvalidCount {
+			t.Fatalf("n = %d: expected ErrInvalidCount, got %v", n, err)
+		}
+	}
+
+	if keys, err := DeriveKeys(master, "viewing key", MAXKEYS); err != nil || len(keys) != MAXKEYS {
+		t.Fatalf("n = MAXKEYS returned %d keys, %v", len(keys), err)
+	}
+}
+
+func TestMAC(t *testing.T) {
+	key := big.NewInt(7)
+
+	msg := make([]*big.Int, 30) // більше двох блоків MACBLOCK
+	for i := range msg {
+		msg[i] = big.NewInt(int64(i + 1))
+	}
+
+	tag, err := MAC(key, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !VerifyMAC(key, msg, tag) {
+		t.Fatalf("valid tag was rejected")
+	}
+
+	if VerifyMAC(big.NewInt(8), msg, tag) {
+		t.Fatalf("tag verified under a different key")
+	}
+
+	tampered := append([]*big.Int{}, msg...)
+	tampered[17] = big.NewInt(100)
+	if VerifyMAC(key, tampered, tag) {
+		t.Fatalf("tag verified for a modified message")
+	}
+
+	// повідомлення, які відрізняються лише нульовими елементами в кінці, мають різні теги
+	extended := append(append([]*big.Int{}, msg...), big.NewInt(0))
+	if VerifyMAC(key, extended, tag) {
+		t.Fatalf("tag verified for a zero-extended message")
+	}
+
+	empty, err := MAC(key, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyMAC(key, nil, empty) || VerifyMAC(key, []*big.Int{big.NewInt(0)}, empty) {
+		t.Fatalf("empty message tag is not bound to the message length")
+	}
+
+	if VerifyMAC(key, msg, new(big.Int).Add(tag, q)) {
+		t.Fatalf("non-canonical tag was accepted")
+	}
+}
@@ -0,0 +1,197 @@
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrInvalidArity  = errors.New("poseidon: merkle tree arity must be in 2..16")
+	ErrNoLeaves      = errors.New("poseidon: merkle tree has no leaves")
+	ErrInvalidLeaf   = errors.New("poseidon: leaf index out of range")
+	ErrInvalidProof  = errors.New("poseidon: malformed merkle proof")
+	ErrProofMismatch = errors.New("poseidon: merkle proof does not match the root")
+)
+
+// Константи розділення доменів дерева Меркла (ASCII "LEAF", "NODE") в елементі ємності state[0], як у keyedPermute:
+// лист гешується як Poseidon([DomainMerkleLeaf, leaf])[0], внутрішній вузол - як Poseidon([DomainMerkleNode, діти...])[0],
+// тому геш листа не може бути прийнятий за внутрішній вузол (і навпаки), а обидва не перетинаються з Hash
+const (
+	DomainMerkleLeaf uint64 = 0x4c454146
+	DomainMerkleNode uint64 = 0x4e4f4445
+)
+
+// merkleLeaf - функція гешування листа дерева Меркла
+func merkleLeaf(leaf *big.Int) *big.Int {
+	return keyedPermute(DomainMerkleLeaf, leaf)
+}
+
+// merkleNode - функція гешування внутрішнього вузла дерева Меркла з arity дітьми
+func merkleNode(children []*big.Int) *big.Int {
+	return keyedPermute(DomainMerkleNode, children[0], children[1:]...)
+}
+
+// MerkleProofStep - один рівень доведення належності листа дереву: позиція вузла серед дітей батьківського вузла
+// і інші arity-1 дітей в порядку зліва направо
+type MerkleProofStep struct {
+	Index    int
+	Siblings []*big.Int
+}
+
+// merkleDepth - функція глибини дерева Меркла з arity дітьми на вузол для leaves листків (як у MerkleRoot, мінімум 1);
+// не переповнюється для leaves аж до math.MaxInt
+func merkleDepth(arity, leaves int) int {
+	depth := 1
+	for n := arity; n < leaves; n *= arity {
+		depth++
+		if n > leaves/arity { // n * arity >= leaves (і може переповнити int)
+			break
+		}
+	}
+
+	return depth
+}
+
+// merkleLevels - функція побудови всіх рівнів дерева з arity дітьми на вузол: нижній рівень - геші листя merkleLeaf,
+// доповнені нулями до arity^depth (depth >= 1; нуль не є гешем жодного листа, тому доповнення не збігається з листом 0),
+// кожен вузол вище дорівнює merkleNode від його дітей; останній рівень містить лише корінь
+func merkleLevels(leaves []*big.Int, arity int) ([][]*big.Int, error) {
+	if arity < 2 || arity > INPUTS {
+		return nil, ErrInvalidArity
+	}
+
+	if len(leaves) == 0 {
+		return nil, ErrNoLeaves
+	}
+
+	width := arity
+	for width < len(leaves) {
+		width *= arity
+	}
+
+	level := make([]*big.Int, width)
+	for i := range level {
+		if i < len(leaves) {
+			if !inField(leaves[i]) {
+				return nil, ErrInvalidInput
+			}
+			level[i] = merkleLeaf(leaves[i])
+		} else {
+			level[i] = new(big.Int)
+		}
+	}
+
+	levels := [][]*big.Int{level}
+
+	for len(level) > 1 {
+		next := make([]*big.Int, len(level)/arity)
+		for i := range next {
+			next[i] = merkleNode(level[i*arity : (i+1)*arity])
+		}
+
+		levels = append(levels, next)
+		level = next
+	}
+
+	return levels, nil
+}
+
+// MerkleRoot - функція обчислення кореня дерева Меркла з arity (2..16) дітьми на вузол: листя гешуються merkleLeaf,
+// вузли - merkleNode (перестановка Poseidon з різними тегами домену), нижній рівень доповнюється нулями до степеня arity
+func MerkleRoot(leaves []*big.Int, arity int) (*big.Int, error) {
+	levels, err := merkleLevels(leaves, arity)
+	if err != nil {
+		return nil, err
+	}
+
+	return levels[len(levels)-1][0], nil
+}
+
+// MerkleProof - функція побудови доведення належності листа з номером index дереву з коренем MerkleRoot(leaves, arity);
+// кроки доведення йдуть від листя до кореня
+func MerkleProof(leaves []*big.Int, arity, index int) ([]MerkleProofStep, error) {
+	levels, err := merkleLevels(leaves, arity)
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 || index >= len(leaves) {
+		return nil, ErrInvalidLeaf
+	}
+
+	proof := make([]MerkleProofStep, 0, len(levels)-1)
+
+	for _, level := range levels[:len(levels)-1] {
+		group := index / arity * arity
+		step := MerkleProofStep{Index: index % arity}
+
+		for i := group; i < group+arity; i++ {
+			if i != index {
+				step.Siblings = append(step.Siblings, level[i])
+			}
+		}
+
+		proof = append(proof, step)
+		index /= arity
+	}
+
+	return proof, nil
+}
+
+// VerifyMerkleProof - функція перевірки доведення належності листа leaf дереву з коренем root, arity дітьми на вузол
+// і leaves листками. Доведення повинно мати рівно стільки кроків, скільки рівнів у такому дереві, по arity-1 сусідів
+// на крок, і вказувати на лист з номером менше leaves (не на доповнення).
+// Повертає ErrInvalidArity для неправильної арності, ErrNoLeaves для leaves <= 0, ErrInvalidProof для некоректного
+// доведення і ErrProofMismatch, якщо обчислений корінь не збігається з root.
+func VerifyMerkleProof(leaf *big.Int, proof []MerkleProofStep, root *big.Int, arity, leaves int) error {
+	if arity < 2 || arity > INPUTS {
+		return ErrInvalidArity
+	}
+
+	if leaves <= 0 {
+		return ErrNoLeaves
+	}
+
+	if len(proof) != merkleDepth(arity, leaves) || !inField(leaf) {
+		return ErrInvalidProof
+	}
+
+	node := merkleLeaf(leaf)
+	index, weight := 0, 1
+
+	for _, step := range proof {
+		if len(step.Siblings)+1 != arity || step.Index < 0 || step.Index >= arity {
+			return ErrInvalidProof
+		}
+
+		if step.Index > 0 && weight > (leaves-1-index)/step.Index { // номер листа не менший за leaves
+			return ErrInvalidProof
+		}
+
+		index += step.Index * weight
+		if weight > leaves/arity { // далі будь-який ненульовий номер виходить за leaves; weight не переповнюється
+			weight = leaves
+		} else {
+			weight *= arity
+		}
+
+		children := make([]*big.Int, 0, arity)
+		children = append(children, step.Siblings[:step.Index]...)
+		children = append(children, node)
+		children = append(children, step.Siblings[step.Index:]...)
+
+		for _, c := range children {
+			if !inField(c) {
+				return ErrInvalidProof
+			}
+		}
+
+		node = merkleNode(children)
+	}
+
+	if node.Cmp(root) != 0 {
+		return ErrProofMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package poseidon
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func testLeaves(n int) []*big.Int {
+	leaves := make([]*big.Int, n)
+	for i := range leaves {
+		leaves[i] = big.NewInt(int64(i*7 + 1))
+	}
+	return leaves
+}
+
+func TestMerkleRoot(t *testing.T) {
+	leaves := testLeaves(3)
+
+	root, err := MerkleRoot(leaves, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zero := new(big.Int)
+	leaf := func(x *big.Int) *big.Int {
+		return permute([]*big.Int{new(big.Int).SetUint64(DomainMerkleLeaf), new(big.Int).Set(x)})[0]
+	}
+	node := func(children ...*big.Int) *big.Int {
+		state := []*big.Int{new(big.Int).SetUint64(DomainMerkleNode)}
+		for _, c := range children {
+			state = append(state, new(big.Int).Set(c))
+		}
+		return permute(state)[0]
+	}
+
+	want := node(node(leaf(leaves[0]), leaf(leaves[1])), node(leaf(leaves[2]), zero))
+	if root.Cmp(want) != 0 {
+		t.Fatalf("root is %s, expected %s", root, want)
+	}
+
+	single, _ := MerkleRoot(leaves[:1], 4)
+	if want := node(leaf(leaves[0]), zero, zero, zero); single.Cmp(want) != 0 {
+		t.Fatalf("single leaf root is %s, expected %s", single, want)
+	}
+
+	// доповнення не збігається з листом 0, а корінь - з Hash вузла
+	padded, _ := MerkleRoot([]*big.Int{leaves[0], zero}, 2)
+	if short, _ := MerkleRoot(leaves[:1], 2); short.Cmp(padded) == 0 {
+		t.Fatalf("trees [a] and [a, 0] have the same root")
+	}
+
+	if root.Cmp(Hash([]*big.Int{node(leaf(leaves[0]), leaf(leaves[1])), node(leaf(leaves[2]), zero)})) == 0 {
+		t.Fatalf("merkle nodes are not separated from Hash")
+	}
+
+	for _, tt := range []struct {
+		leaves []*big.Int
+		arity  int
+		err    error
+	}{
+		{leaves: leaves, arity: 1, err: ErrInvalidArity},
+		{leaves: leaves, arity: INPUTS + 1, err: ErrInvalidArity},
+		{leaves: nil, arity: 2, err: ErrNoLeaves},
+		{leaves: []*big.Int{new(big.Int).Set(q)}, arity: 2, err: ErrInvalidInput},
+	} {
+		if _, err := MerkleRoot(tt.leaves, tt.arity); !errors.Is(err, tt.err) {
+			t.Fatalf("arity %d: expected %v, got %v", tt.arity, tt.err, err)
+		}
+	}
+}
+
+func TestMerkleProof(t *testing.T) {
+	for _, arity := range []int{2, 3, 16} {
+		leaves := testLeaves(20)
+		root, _ := MerkleRoot(leaves, arity)
+
+		for i := range leaves {
+			proof, err := MerkleProof(leaves, arity, i)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := VerifyMerkleProof(leaves[i], proof, root, arity, len(leaves)); err != nil {
+				t.Fatalf("arity %d, leaf %d: %v", arity, i, err)
+			}
+
+			if err := VerifyMerkleProof(big.NewInt(0), proof, root, arity, len(leaves)); !errors.Is(err, ErrProofMismatch) {
+				t.Fatalf("arity %d, leaf %d: proof verified for a wrong leaf", arity, i)
+			}
+		}
+	}
+
+	leaves := testLeaves(5)
+	proof, _ := MerkleProof(leaves, 2, 0)
+	root, _ := MerkleRoot(leaves, 2)
+
+	for _, tt := range []struct {
+		name   string
+		proof  []MerkleProofStep
+		arity  int
+		leaves int
+		err    error
+	}{
+		{name: "short proof", proof: proof[:2], arity: 2, leaves: 5, err: ErrInvalidProof},
+		{name: "long proof", proof: append(proof[:3:3], proof[2]), arity: 2, leaves: 5, err: ErrInvalidProof},
+		{name: "wrong leaf count", proof: proof, arity: 2, leaves: 16, err: ErrInvalidProof},
+		{name: "wrong arity", proof: proof, arity: 4, leaves: 5, err: ErrInvalidProof},
+		{name: "invalid arity", proof: proof, arity: 1, leaves: 5, err: ErrInvalidArity},
+		{name: "no leaves", proof: proof, arity: 2, leaves: 0, err: ErrNoLeaves},
+	} {
+		if err := VerifyMerkleProof(leaves[0], tt.proof, root, tt.arity, tt.leaves); !errors.Is(err, tt.err) {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.err, err)
+		}
+	}
+
+	// внутрішній вузол не можна подати як лист коротшого доведення
+	levels, _ := merkleLevels(leaves, 2)
+	if err := VerifyMerkleProof(levels[1][0], proof[1:], root, 2, 3); err == nil {
+		t.Fatalf("inner node verified as a leaf")
+	}
+
+	// доведення для позиції доповнення (лист 5 дерева з 5 листків) відхиляється за номером листа
+	padding, _ := MerkleProof(append(leaves, big.NewInt(0)), 2, 5)
+	if err := VerifyMerkleProof(big.NewInt(0), padding, root, 2, 5); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("proof for a padding position returned %v", err)
+	}
+
+	malformed := append([]MerkleProofStep(nil), proof...)
+	malformed[1].Siblings = malformed[1].Siblings[:0]
+	if err := VerifyMerkleProof(leaves[0], malformed, root, 2, 5); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("malformed proof returned %v", err)
+	}
+
+	if _, err := MerkleProof(leaves, 2, 5); !errors.Is(err, ErrInvalidLeaf) {
+		t.Fatalf("out of range index returned %v", err)
+	}
+}
+
+// TestMerkleDepthOverflow - глибина і перевірка доведення для leaves = math.MaxInt не переповнюються і не зациклюються
+func TestMerkleDepthOverflow(t *testing.T) {
+	maxLeaves := big.NewInt(math.MaxInt)
+
+	for arity := 2; arity <= INPUTS; arity++ {
+		want := 1 // найменша глибина, для якої arity^depth >= math.MaxInt
+		for n := big.NewInt(int64(arity)); n.Cmp(maxLeaves) < 0; n.Mul(n, big.NewInt(int64(arity))) {
+			want++
+		}
+
+		depth := merkleDepth(arity, math.MaxInt)
+		if depth != want {
+			t.Fatalf("arity %d: merkleDepth(MaxInt) = %d, expected %d", arity, depth, want)
+		}
+
+		first := make([]MerkleProofStep, depth) // лист 0
+		last := make([]MerkleProofStep, depth)  // лист arity^depth - 1 >= math.MaxInt
+		for i := range first {
+			first[i].Siblings = make([]*big.Int, arity-1)
+			for j := range first[i].Siblings {
+				first[i].Siblings[j] = big.NewInt(0)
+			}
+			last[i] = MerkleProofStep{Index: arity - 1, Siblings: first[i].Siblings}
+		}
+
+		if err := VerifyMerkleProof(big.NewInt(1), first, big.NewInt(0), arity, math.MaxInt); !errors.Is(err, ErrProofMismatch) {
+			t.Fatalf("arity %d: proof for leaf 0 returned %v", arity, err)
+		}
+		if err := VerifyMerkleProof(big.NewInt(1), last, big.NewInt(0), arity, math.MaxInt); !errors.Is(err, ErrInvalidProof) {
+			t.Fatalf("arity %d: proof for an out of range leaf returned %v", arity, err)
+		}
+	}
+}
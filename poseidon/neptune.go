@@ -0,0 +1,121 @@
+package poseidon
+
+import (
+	"math"
+	"math/big"
+	"sync"
+)
+
+// Strength - стійкість параметрів Neptune: кількість часткових раундів
+type Strength int
+
+const (
+	Standard     Strength = iota // раунди з запасом стійкості (R_F + 2, R_P * 1.075), як Strength::Standard у Neptune
+	Strengthened                 // кількість часткових раундів Standard, збільшена на 25% з округленням вгору
+)
+
+// blsR - порядок скалярного поля BLS12-381 (поле Neptune і Filecoin)
+var blsR, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// neptuneArities - арності Neptune, які використовує Filecoin (U2, U4, U8, U11)
+var neptuneArities = []int{2, 4, 8, 11}
+
+var (
+	neptuneOnce   [4][2]sync.Once
+	neptuneTables [4][2]fieldPermutation // за індексом арності в neptuneArities і стійкістю
+)
+
+// neptuneSecure - функція перевірки стійкості (128 бітів, поле 255 бітів) кількості раундів за атаками
+// статистичною, інтерполяційною і Гребнера, як у Neptune
+func neptuneSecure(t, rf, rp int) bool {
+	const n, m = 255.0, 128.0
+	T, RP := float64(t), float64(rp)
+
+	rfStat := 10.0
+	if m <= (n-3)*(T+1) {
+		rfStat = 6
+	}
+
+	rfMax := math.Max(math.Max(rfStat, 0.43*m+math.Log2(T)-RP), math.Max(0.21*n-RP, (0.14*n-1-RP)/(T-1)))
+
+	return float64(rf) >= math.Ceil(rfMax)
+}
+
+// neptuneRounds - функція кількості повних і часткових раундів Neptune для ширини t: мінімум S-блоків
+// t*rf + rp серед стійких комбінацій з запасом (rf + 2, rp * 1.075); Strengthened збільшує rp на 25%
+func neptuneRounds(t int, strength Strength) (int, int) {
+	rf, rp, best := 0, 0, math.MaxInt
+
+	for rfTest := 2; rfTest <= 1000; rfTest += 2 {
+		for rpTest := 4; rpTest < 200; rpTest++ {
+			if !neptuneSecure(t, rfTest, rpTest) {
+				continue
+			}
+
+			f, p := rfTest+2, int(math.Ceil(1.075*float64(rpTest)))
+			if sboxes := t*f + p; sboxes < best || (sboxes == best && f < rf) {
+				rf, rp, best = f, p, sboxes
+			}
+		}
+	}
+
+	if strength == Strengthened {
+		rp = (rp*5 + 3) / 4
+	}
+
+	return rf, rp
+}
+
+// neptuneIndex - функція індексу арності в neptuneArities (-1 для непідтримуваної арності)
+func neptuneIndex(arity int) int {
+	for i, a := range neptuneArities {
+		if a == arity {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// neptuneConstants - функція отримання параметрів Neptune (обчислюються один раз при першому виклику):
+// константи раундів - Grain LFSR з sbox = 1, матриця Коші m[i][j] = 1 / (i + t + j)
+func neptuneConstants(index int, strength Strength) *fieldPermutation {
+	neptuneOnce[index][strength].Do(func() {
+		t := neptuneArities[index] + 1
+		fp := &neptuneTables[index][strength]
+		fp.p, fp.alpha = blsR, big5int
+		fp.nRoundsF, fp.nRoundsP = neptuneRounds(t, strength)
+
+		g := newGrain(1, 255, t, fp.nRoundsF, fp.nRoundsP)
+		fp.c = make([]*big.Int, (fp.nRoundsF+fp.nRoundsP)*t)
+		for i := range fp.c {
+			fp.c[i] = g.field(blsR, 255)
+		}
+
+		xs, ys := make([]*big.Int, t), make([]*big.Int, t)
+		for i := range xs {
+			xs[i], ys[i] = big.NewInt(int64(i)), big.NewInt(int64(t+i))
+		}
+		fp.m = cauchyMatrix(xs, ys, blsR)
+	})
+
+	return &neptuneTables[index][strength]
+}
+
+// hashNeptune - функція гешування arity елементів BLS12-381 Fr як вузла дерева Меркла Neptune
+func hashNeptune(input []*big.Int, strength Strength) (*big.Int, error) {
+	index := neptuneIndex(len(input))
+	if index < 0 {
+		return nil, ErrInputsLength
+	}
+
+	elems, err := fieldElements(input, blsR)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(len(input))), big.NewInt(1))
+	state := append([]*big.Int{tag}, elems...)
+
+	return neptuneConstants(index, strength).permute(state)[1], nil
+}
@@ -0,0 +1,118 @@
+package poseidon
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestNeptuneRounds(t *testing.T) {
+	// таблиця раундів Neptune (round_numbers.rs) для ширин 3..17
+	standard := map[int]int{3: 55, 4: 56, 5: 56, 6: 56, 7: 56, 8: 57, 9: 57, 10: 57, 11: 57, 12: 57, 13: 57, 14: 57, 15: 57, 16: 59, 17: 59}
+
+	for width, nRoundsP := range standard {
+		if rf, rp := neptuneRounds(width, Standard); rf != 8 || rp != nRoundsP {
+			t.Fatalf("width %d: Standard rounds are (%d, %d), expected (8, %d)", width, rf, rp, nRoundsP)
+		}
+	}
+
+	for width, nRoundsP := range map[int]int{3: 69, 5: 70, 9: 72, 12: 72} {
+		if rf, rp := neptuneRounds(width, Strengthened); rf != 8 || rp != nRoundsP {
+			t.Fatalf("width %d: Strengthened rounds are (%d, %d), expected (8, %d)", width, rf, rp, nRoundsP)
+		}
+	}
+}
+
+func TestNeptuneConstants(t *testing.T) {
+	// перші константи раундів і матриця для арності 11 з опублікованої таблиці констант Neptune (t = 12, R_F = 8, R_P = 57)
+	nc := neptuneConstants(neptuneIndex(11), Standard)
+
+	if len(nc.c) != (8+57)*12 {
+		t.Fatalf("%d round constants, expected %d", len(nc.c), (8+57)*12)
+	}
+
+	for i, want := range []string{
+		"1f6c9576e648b5047399bfc5f38902e0d506f18e0f3ab77de6de096bd089bce4",
+		"06deba7028df3debd71993e243a5a74768fdf04db7ede8b2258be924c8f08ca9",
+		"590cd6c13291cdfa85d9c68dc29a5aa033c58fd1f901e450cc6c5e5dfb02ac09",
+	} {
+		if got := fmt.Sprintf("%064x", nc.c[i]); got != want {
+			t.Fatalf("round constant %d is %s, expected %s", i, got, want)
+		}
+	}
+
+	for j, want := range []string{
+		"6a44840c3b7b082cd99fb0b208d45b5a376dd6581553d4546aaaaaa9c0000001",
+		"6217dc5a0f85429f8dce7bb808267bb5bd02ed3d9d88753a3b13b13a3b13b13c",
+		"4a867dda0877876545809d29bd0c9d27fef9e96fa4913b23edb6db6d12492493",
+	} {
+		if got := fmt.Sprintf("%064x", nc.m[0][j]); got != want {
+			t.Fatalf("m[0][%d] is %s, expected %s", j, got, want)
+		}
+	}
+}
+
+func TestHashNeptune(t *testing.T) {
+	// вхід 0..arity-1, як у тесті hash_values у Neptune; вектори Standard для арностей 2 і 4 збігаються з
+	// опублікованими в Neptune, усі вектори перевірено незалежною реалізацією (triplewz/poseidon, режим Correct)
+	vectors := []struct {
+		strength Strength
+		arity    int
+		hash     string
+	}{
+		{Standard, 2, "396508d75e76a56b739e0fd902efe161a6fba9339d05a69d2e203c369a02e7ff"},
+		{Standard, 4, "58a54b10a9e5848a00db3c6579229399fb6b4605bf1327ec019814ff6662075d"},
+		{Standard, 8, "2394611da3a5de5512010042116770774b682e9d9cc4aed92a9934f56d38a5e6"},
+		{Standard, 11, "0c0fc1b2e5227f286ca537e232ebe87a09f3dcd8ccb08fc1cee3bbc32b693163"},
+		{Strengthened, 2, "33d28a753baee41bc48b36ecc4cab7485278ecbf17040ea6793dbaf54552cd69"},
+		{Strengthened, 4, "09d8207c51ca3f4354013bdaf68ba4c2e5113a254d6f5c7e4650ee190212aa9a"},
+		{Strengthened, 8, "69e61465981ae17ed69aae8fe1cb63e8e843d4cfba662df19f0c3c93c3fc894e"},
+		{Strengthened, 11, "28bb83ff439753c007abbcf9b406e8d8c94fe2ca3f46d433778af344d8f9e8b7"},
+	}
+
+	for _, v := range vectors {
+		input := make([]*big.Int, v.arity)
+		for i := range input {
+			input[i] = big.NewInt(int64(i))
+		}
+
+		got, err := HashWithOptions(input, HashOptions{Variant: VariantNeptune, Strength: v.strength})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want, _ := new(big.Int).SetString(v.hash, 16); got.Cmp(want) != 0 {
+			t.Fatalf("arity %d, strength %d: hash is %x, expected %s", v.arity, v.strength, got, v.hash)
+		}
+
+		for i, x := range input {
+			if x.Int64() != int64(i) {
+				t.Fatalf("arity %d: input[%d] was modified", v.arity, i)
+			}
+		}
+	}
+
+	// вектор triplewz/poseidon для двох нулів
+	got, err := HashWithOptions(ints(0, 0), HashOptions{Variant: VariantNeptune})
+	if err != nil || got.Text(16) != "48fe0b1331196f6cdb33a7c6e5af61b76fd388e1ef1d3d418be5147f0e4613d4" {
+		t.Fatalf("hash of (0, 0) is %x, %v", got, err)
+	}
+
+	// елементи BLS12-381 Fr більші за q з BN254 допустимі, r - ні
+	if _, err := HashWithOptions([]*big.Int{q, new(big.Int).Sub(blsR, big.NewInt(1))}, HashOptions{Variant: VariantNeptune}); err != nil {
+		t.Fatalf("elements q and r-1 returned %v", err)
+	}
+
+	for _, input := range [][]*big.Int{{blsR, big.NewInt(0)}, {big.NewInt(-1), big.NewInt(0)}, {nil, big.NewInt(0)}} {
+		if _, err := HashWithOptions(input, HashOptions{Variant: VariantNeptune}); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("input %v returned %v", input, err)
+		}
+	}
+
+	for _, n := range []int{0, 1, 3, 5, 16} {
+		if _, err := HashWithOptions(make([]*big.Int, n), HashOptions{Variant: VariantNeptune}); !errors.Is(err, ErrInputsLength) {
+			t.Fatalf("%d inputs returned %v", n, err)
+		}
+	}
+}
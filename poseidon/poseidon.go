@@ -0,0 +1,336 @@
+package poseidon
+
+import (
+	"math/big"
+	"sync"
+)
+
+const (
+	NROUNDSF = 8  // кількість раундів
+	INPUTS   = 16 // кількість елементів в масиві, який передається в функцію Hash
+	SBLOCK   = 31 // розмір блоку, на який розбивається вхідний зріз байтів
+)
+
+var NROUNDSP = []int{56, 57, 56, 60, 60, 63, 64, 63, 60, 66, 60, 65, 70, 60, 64, 68}                                   // раунди які використовуються для кожної кількості елементів в масиві, який передається в функцію Hash
+var q, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10) // константа q (за допомогою якої відбувається обчислення по модулю q)
+var big5int *big.Int = big.NewInt(5)                                                                                   // константа 5 для піднесення до ступеню 5
+
+func addRoundKeys(state []*big.Int, constants []*big.Int, r int) {
+	var wg sync.WaitGroup
+
+	for i := range state {
+		wg.Add(1) // Додаємо горутину до групи
+		go func(i int) {
+			defer wg.Done() // Позначаємо горутину як завершену
+			state[i].Add(state[i], constants[r+i]).Mod(state[i], q)
+		}(i)
+	}
+
+	wg.Wait() // Очікуємо на завершення всіх горутин
+}
+
+// mix - функція перемішування елементів state
+func mix(state []*big.Int, countElements int, matr [][]*big.Int) []*big.Int {
+	newState := make([]*big.Int, countElements)
+	cache := make([]*big.Int, len(matr))
+	mul := new(big.Int)
+
+	initCh := make(chan int, len(newState)) // буферизований канал для ініціалізації newState
+	constCh := make(chan int, len(matr))    // буферизований канал для додавання констант
+
+	for i := range newState {
+		initCh <- i // додаємо індекси до каналу для ініціалізації newState
+	}
+	close(initCh)
+
+	for i := range matr {
+		constCh <- i // додаємо індекси до каналу для додавання констант
+	}
+	close(constCh)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < len(newState); i++ {
+		wg.Add(1)
+		go func() { // горутина для ініціалізації newState
+			defer wg.Done()
+			for i := range initCh {
+				newState[i] = big.NewInt(0)
+			}
+		}()
+	}
+
+	for i := 0; i < len(matr); i++ {
+		wg.Add(1)
+		go func() { // горутина для додавання констант
+			defer wg.Done()
+			for i := range constCh {
+				cache[i] = new(big.Int).SetInt64(0)
+			}
+		}()
+	}
+
+	wg.Wait() // чекаємо завершення всіх горутин
+
+	for i := 0; i < countElements; i++ {
+		for j, p := range matr {
+			cache[j].Mul(p[i], state[j])
+		}
+
+		for _, c := range cache {
+			mul.Add(mul, c)
+		}
+
+		newState[i].Mod(mul, q)
+		mul.SetInt64(0)
+	}
+
+	return newState
+}
+
+// bigIntPool - пул тимчасових *big.Int для проміжних значень exp5; об'єкт з пулу використовується лише всередині
+// функції, яка його взяла, і повертається в пул до її завершення - результат ніколи не посилається на об'єкт з пулу
+var bigIntPool = sync.Pool{
+	New: func() interface{} {
+		return new(big.Int)
+	},
+}
+
+// exp5 - функція піднесення елемента поля x до ступеню 5 по модулю q на місці (x = x^5 mod q); повертає x.
+// Проміжні x^2 і x^4 обчислюються в тимчасовому об'єкті з пулу.
+func exp5(x *big.Int) *big.Int {
+	buf := bigIntPool.Get().(*big.Int)
+	defer bigIntPool.Put(buf)
+
+	buf.Mul(x, x).Mod(buf, q)     // x^2
+	buf.Mul(buf, buf).Mod(buf, q) // x^4
+
+	return x.Mul(x, buf).Mod(x, q)
+}
+
+// exp5state - функція піднесення до ступеню 5 кожного елементу масиву state
+func exp5state(state []*big.Int) []*big.Int {
+	var wg sync.WaitGroup
+
+	for i := range state {
+		wg.Add(1)        // Додаємо горутину до групи
+		go func(i int) { // Горутина для кожного елементу state для паралельного виконання піднесення до ступеню 5
+			defer wg.Done() // Позначаємо горутину як завершену
+			exp5(state[i])
+		}(i)
+	}
+
+	wg.Wait() // Очікуємо на завершення всіх горутин
+
+	return state
+}
+
+// permute - перестановка Poseidon над вектором стану state, ширина якого (2..17) визначає набір констант;
+// елементи state змінюються на місці, результатом є новий стан після всіх раундів
+func permute(state []*big.Int) []*big.Int {
+	return permuteTraced(state, nil)
+}
+
+// traceHook - функція, яка викликається після кожного кроку перестановки: round - номер раунду
+// (0..NROUNDSF+NROUNDSP-1; для "ark" - раунд, константи якого додаються, див. TraceStep), partial - чи виконується
+// крок у частковому раунді, op - крок ("ark", "sbox" або "mix"), state - поточний стан (використовується лише під час
+// виклику і змінюється наступними кроками)
+type traceHook func(round int, partial bool, op string, state []*big.Int)
+
+// permuteTraced - перестановка permute, яка після кожного кроку викликає trace (якщо trace не nil)
+func permuteTraced(state []*big.Int, trace traceHook) []*big.Int {
+	countElements := len(state)
+
+	nRoundsF := NROUNDSF
+	nRoundsP := NROUNDSP[countElements-2]
+
+	// константи для даної ширини стану
+	C := c.c[countElements-2]
+	S := c.s[countElements-2]
+	M := c.m[countElements-2]
+	P := c.p[countElements-2]
+
+	step := func(round int, partial bool, op string) {
+		if trace != nil {
+			trace(round, partial, op, state)
+		}
+	}
+
+	addRoundKeys(state, C, 0)
+	step(0, false, "ark")
+
+	for i := 0; i < nRoundsF/2-1; i++ {
+		state = exp5state(state) // піднесення до ступеню 5 кожного елементу масиву state
+		step(i, false, "sbox")
+		addRoundKeys(state, C, (i+1)*countElements) // додавання константи до кожного елементу масиву state
+		step(i+1, false, "ark")
+		state = mix(state, countElements, M) // перемішування елементів масиву state за допомогою матриці M
+		step(i, false, "mix")
+	}
+
+	state = exp5state(state)
+	step(nRoundsF/2-1, false, "sbox")
+	addRoundKeys(state, C, (nRoundsF/2)*countElements)
+	step(nRoundsF/2, false, "ark")
+	state = mix(state, countElements, P)
+	step(nRoundsF/2-1, false, "mix")
+
+	mul := big.NewInt(0)
+	newState0 := big.NewInt(0)
+
+	for i := 0; i < nRoundsP; i++ {
+		exp5(state[0])
+		step(nRoundsF/2+i, true, "sbox")
+		state[0].Add(state[0], C[(nRoundsF/2+1)*countElements+i]) // додавання константи до елементу state[0]
+		state[0].Mod(state[0], q)
+		step(nRoundsF/2+i+1, true, "ark")
+
+		mul.SetInt64(0)
+		newState0.SetInt64(0)
+
+		for j := range state {
+			mul.Mul(S[(countElements*2-1)*i+j], state[j])
+			newState0.Add(newState0, mul)
+			newState0.Mod(newState0, q)
+		}
+
+		for k := 1; k < countElements; k++ {
+			mul.SetInt64(0)
+			state[k].Add(state[k], mul.Mul(state[0], S[(countElements*2-1)*i+countElements+k-1]))
+			state[k].Mod(state[k], q)
+		}
+		state[0], newState0 = newState0, state[0] // newState0 переходить в стан, а старий state[0] стає буфером наступного раунду
+		step(nRoundsF/2+i, true, "mix")
+	}
+
+	for i := 0; i < nRoundsF/2-1; i++ {
+		state = exp5state(state)
+		step(nRoundsF/2+nRoundsP+i, false, "sbox")
+		addRoundKeys(state, C, (nRoundsF/2+1)*countElements+nRoundsP+i*countElements)
+		step(nRoundsF/2+nRoundsP+i+1, false, "ark")
+		state = mix(state, countElements, M)
+		step(nRoundsF/2+nRoundsP+i, false, "mix")
+	}
+
+	state = exp5state(state)
+	step(nRoundsF+nRoundsP-1, false, "sbox")
+	state = mix(state, countElements, M)
+	step(nRoundsF+nRoundsP-1, false, "mix")
+
+	return state
+}
+
+// copyState - функція перевірки і копіювання вектора стану для Permute і InversePermute
+func copyState(state []*big.Int) ([]*big.Int, error) {
+	if len(state) < 2 || len(state) > INPUTS+1 {
+		return nil, ErrInputsLength
+	}
+
+	s := make([]*big.Int, len(state))
+	for i, x := range state {
+		if !inField(x) {
+			return nil, ErrInvalidInput
+		}
+		s[i] = new(big.Int).Set(x)
+	}
+
+	return s, nil
+}
+
+// Permute - функція перестановки Poseidon над вектором стану ширини 2..17 (Hash(input) - перший елемент
+// Permute([0, input...])). Вхідний масив не змінюється; повертає ErrInputsLength для неправильної ширини
+// і ErrInvalidInput, якщо якийсь елемент не в межах [0, q).
+func Permute(state []*big.Int) ([]*big.Int, error) {
+	s, err := copyState(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return permute(s), nil
+}
+
+// Hash - функція гешування вхідного масиву елементів типу *big.Int в один елемент типу *big.Int.
+// Вхід не перевіряється: елементи, не менші за q, зводяться за модулем q, а для 0 або більше 16 елементів
+// функція панікує; перевірку, як у go-iden3-crypto, виконують Hasher.Hash і HashElements.
+func Hash(input []*big.Int) *big.Int {
+	state := make([]*big.Int, len(input)+1)
+	state[0] = big.NewInt(0)
+
+	for i, x := range input { // копіюємо вхідні елементи, щоб перестановка не змінювала масив input
+		state[i+1] = new(big.Int).Set(x)
+	}
+
+	return permute(state)[0]
+}
+
+// sponge - губка над елементами поля з кадром з INPUTS елементів (сумісна з SpongeHash з iden3):
+// кадр заповнюється елементами, після заповнення гешується, і геш стає першим елементом наступного кадру
+type sponge struct {
+	inputs [INPUTS]*big.Int // масив елементів типу *big.Int, які передаються в функцію Hash
+	hash   *big.Int         // геш останнього заповненого кадру (nil, якщо кадр ще не гешувався)
+	dirty  bool             // чи є в кадрі елементи, які ще не були загешовані
+	k      int              // індекс елемента кадру, який заповнюється
+}
+
+// newSponge - функція створення губки з кадром, ініціалізованим нулями
+func newSponge() *sponge {
+	s := &sponge{}
+	for j := range s.inputs {
+		s.inputs[j] = new(big.Int)
+	}
+
+	return s
+}
+
+// absorb - функція поглинання одного елемента поля
+func (s *sponge) absorb(e *big.Int) {
+	s.inputs[s.k].Set(e)
+	s.dirty = true
+
+	if s.k == INPUTS-1 { // якщо масив елементів типу *big.Int заповнений, то викликаємо функцію Hash
+		s.hash = Hash(s.inputs[:])
+		s.dirty = false
+
+		s.inputs[0].Set(s.hash)       // перший елемент масиву елементів типу *big.Int стає результатом виклику функції Hash
+		for j := 1; j < INPUTS; j++ { // інші елементи масиву елементів типу *big.Int ініціалізуються нулями
+			s.inputs[j].SetUint64(0)
+		}
+		s.k = 1
+	} else {
+		s.k++
+	}
+}
+
+// sum - функція обчислення гешу всіх поглинутих елементів; стан губки не змінюється
+func (s *sponge) sum() *big.Int {
+	if s.dirty || s.hash == nil { // останній кадр ще не загешований (або повідомлення порожнє)
+		return Hash(s.inputs[:])
+	}
+
+	return new(big.Int).Set(s.hash)
+}
+
+// absorbElements - функція гешування масиву елементів поля губкою sponge
+func absorbElements(elems []*big.Int) *big.Int {
+	s := newSponge()
+	for _, e := range elems {
+		s.absorb(e)
+	}
+
+	return s.sum()
+}
+
+// HashBytes - функція гешування вхідного масиву байтів в один елемент типу *big.Int.
+// Масив розбивається на блоки по SBLOCK байтів (big-endian, останній блок доповнюється нулями) - це legacy-кодування,
+// сумісне з HashBytes з iden3; інші кодування доступні через HashBytesWithOptions.
+//
+// Legacy-кодування не прив'язує довжину повідомлення, тому:
+//   - повідомлення, які відрізняються лише нульовими байтами або цілими нульовими блоками в кінці кадру, мають однаковий геш
+//     (наприклад, "abc" і "abc\x00");
+//   - для повідомлення m довжиною, кратною SBLOCK*INPUTS, геш m||m2 обчислюється з HashBytes(m) без знання m
+//     (продовження гешу).
+//
+// Для нових застосувань потрібно використовувати HashBytesStrict.
+func HashBytes(msg []byte) *big.Int {
+	return absorbElements(encodeChunks(msg, len(msg)*8, SBLOCK*8, BigEndian))
+}
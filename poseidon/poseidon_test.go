@@ -0,0 +1,437 @@
+package poseidon
+
+import (
+	"bufio"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"testing/quick"
+
+	"github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+// ints - функція створення масиву елементів поля з int64
+func ints(values ...int64) []*big.Int {
+	elems := make([]*big.Int, len(values))
+	for i, v := range values {
+		elems[i] = big.NewInt(v)
+	}
+	return elems
+}
+
+// publishedVectors - опубліковані тестові вектори Poseidon над BN254 з константами circomlib; source - файл і тест,
+// де опубліковано значення. Покриті кількості входів: 1, 2, 4, 5, 6, 14, 16.
+var publishedVectors = []struct {
+	source string
+	input  []*big.Int
+	want   string
+}{
+	// go-iden3-crypto v0.0.14, poseidon/poseidon_test.go, TestPoseidonHash
+	{"go-iden3-crypto TestPoseidonHash", ints(1), "18586133768512220936620570745912940619677854269274689475585506675881198879027"},
+	{"go-iden3-crypto TestPoseidonHash", ints(1, 2, 0, 0, 0), "1018317224307729531995786483840663576608797660851238720571059489595066344487"},
+	{"go-iden3-crypto TestPoseidonHash", ints(1, 2, 0, 0, 0, 0), "15336558801450556532856248569924170992202208561737609669134139141992924267169"},
+	{"go-iden3-crypto TestPoseidonHash", ints(3, 4, 0, 0, 0), "5811595552068139067952687508729883632420015185677766880877743348592482390548"},
+	{"go-iden3-crypto TestPoseidonHash", ints(3, 4, 0, 0, 0, 0), "12263118664590987767234828103155242843640892839966517009184493198782366909018"},
+	{"go-iden3-crypto TestPoseidonHash", ints(1, 2, 3, 4, 5, 6), "20400040500897583745843009878988256314335038853985262692600694741116813247201"},
+	{"go-iden3-crypto TestPoseidonHash", ints(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14), "8354478399926161176778659061636406690034081872658507739535256090879947077494"},
+	{"go-iden3-crypto TestPoseidonHash", ints(1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 0, 0, 0, 0), "5540388656744764564518487011617040650780060800286365721923524861648744699539"},
+	{"go-iden3-crypto TestPoseidonHash", ints(1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 0, 0, 0, 0, 0, 0), "11882816200654282475720830292386643970958445617880627439994635298904836126497"},
+	{"go-iden3-crypto TestPoseidonHash", ints(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16), "9989051620750914585850546081941653841776809718687451684622678807385399211877"},
+	// circomlibjs, test/poseidon.js: перестановки poseidonperm_x5_254_3 і poseidonperm_x5_254_5 зі стану [0, входи...]
+	{"circomlibjs poseidonperm_x5_254_3", ints(1, 2), "7853200120776062878684798364095072458815029376092732009249414926327459813530"},
+	{"circomlibjs poseidonperm_x5_254_5", ints(1, 2, 3, 4), "18821383157269793795438455681495246036402687001665670618754263018637548127333"},
+	// circomlib, test/poseidoncircuit.js: hash([3, 4]) t=3
+	{"circomlib poseidoncircuit hash([3, 4]) t=3", ints(3, 4), "14763215145315200506921711489642608356394854266165572616578112107564877678998"},
+}
+
+// arityVectors - регресійні вектори для кожної кількості входів 1..16: Hash([1, 2, ..., n]) і Hash([q-1, ..., q-1]).
+// Значення згенеровані цією реалізацією і при генерації звірені з go-iden3-crypto; вони не опубліковані, тому фіксують
+// лише відсутність змін. Для n = 1, 2, 4, 6, 14, 16 Hash([1..n]) збігається з publishedVectors; для n = 3, 7..13, 15
+// опублікованих векторів немає, тому TestHashArityVectors для кожної арності додатково порівнює результат з go-iden3-crypto.
+var arityVectors = []struct {
+	n        int
+	sequence string
+	maximum  string
+}{
+	{1, "18586133768512220936620570745912940619677854269274689475585506675881198879027", "3366645945435192953002076803303112651887535928162668198103357554665518664470"},
+	{2, "7853200120776062878684798364095072458815029376092732009249414926327459813530", "20092309280547939997162506796691455192771288143174894022739895715370814071035"},
+	{3, "6542985608222806190361240322586112750744169038454362455181422643027100751666", "18683487716961139917025852198486848170447084985408220123090811624676101526002"},
+	{4, "18821383157269793795438455681495246036402687001665670618754263018637548127333", "6787226826147679890210956261533278127703365090202917080879592273165705475059"},
+	{5, "6183221330272524995739186171720101788151706631170188140075976616310159254464", "14245385636416310751802326058548440958818944099491829547963012562257855165452"},
+	{6, "20400040500897583745843009878988256314335038853985262692600694741116813247201", "11033590402973890713137943635061562165205989768129788025847706951102774363109"},
+	{7, "12748163991115452309045839028154629052133952896122405799815156419278439301912", "20744891333876835318033165554771725012992868252019864557103881868775028786618"},
+	{8, "18604317144381847857886385684060986177838410221561136253933256952257712543953", "8228397539102454841040442485534067684405352914310516002871243497946030268521"},
+	{9, "13589767895268936107593642967621470491511464502761040466226072462545218539640", "4260567874532772508280390925184585566297818981245997901374203081695372217577"},
+	{10, "3657500514307717306974218405144578736633140001277925127187636780142269815841", "11357969442071354279861432212599495367937618931185336583006188443337805485436"},
+	{11, "3572015662710076994097916907865950486270383304442561406230608893458731714472", "6520074961476687845188793537311766848117799448400619712252818926594468240709"},
+	{12, "2501997477381648492950318384533644783248002172679259592360114615426357826485", "19954545845259530224833196457776832669602840150862728308568673102687953039531"},
+	{13, "7041832639553862712666971417715061873827921493498355005117622707743491651590", "1741020268736754253602491397245375039842204800614885095169907708739832075579"},
+	{14, "8354478399926161176778659061636406690034081872658507739535256090879947077494", "15275242934699873940794566973456339970803332031305897203500222030575683556882"},
+	{15, "4203130618016961831408770638653325366880478848856764494148034853759773445968", "9729424328422688582784102042761158757807600495063176786486662882378551578141"},
+	{16, "9989051620750914585850546081941653841776809718687451684622678807385399211877", "16332601902232930393355625525409967975467257896806612874895075149317175824059"},
+}
+
+func TestHashPublishedVectors(t *testing.T) {
+	for _, tt := range publishedVectors {
+		want, _ := new(big.Int).SetString(tt.want, 10)
+
+		if got := Hash(tt.input); got.Cmp(want) != 0 {
+			t.Errorf("%s: Hash(%v) = %s, expected %s", tt.source, tt.input, got, want)
+		}
+	}
+}
+
+func TestHashArityVectors(t *testing.T) {
+	if len(arityVectors) != INPUTS {
+		t.Fatalf("expected vectors for %d arities, got %d", INPUTS, len(arityVectors))
+	}
+
+	qm1 := new(big.Int).Sub(q, big.NewInt(1))
+
+	for _, tt := range arityVectors {
+		sequence := make([]*big.Int, tt.n)
+		maximum := make([]*big.Int, tt.n)
+		for i := range sequence {
+			sequence[i] = big.NewInt(int64(i + 1))
+			maximum[i] = qm1
+		}
+
+		for _, in := range []struct {
+			input []*big.Int
+			want  string
+		}{{sequence, tt.sequence}, {maximum, tt.maximum}} {
+			got := Hash(in.input)
+			if got.String() != in.want {
+				t.Errorf("arity %d: Hash(%v) = %s, expected %s", tt.n, in.input, got, in.want)
+			}
+
+			if lib, err := poseidon.Hash(in.input); err != nil || lib.Cmp(got) != 0 {
+				t.Errorf("arity %d: Hash(%v) = %s, go-iden3-crypto returned %v, %v", tt.n, in.input, got, lib, err)
+			}
+		}
+	}
+}
+
+// TestHashBytesVectors - перевіряє HashBytes для всіх довжин 0..600 (межі блоку 31 байт і кадру 496 байтів)
+// за векторами з testdata/hashbytes_vectors.txt, які збігаються з HashBytes з go-iden3-crypto
+func TestHashBytesVectors(t *testing.T) {
+	f, err := os.Open("testdata/hashbytes_vectors.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	seen := make(map[int]bool)
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			t.Fatalf("malformed vector line %q", line)
+		}
+
+		n, err := strconv.Atoi(fields[0])
+		if err != nil {
+			t.Fatalf("malformed vector line %q", line)
+		}
+		seen[n] = true
+
+		if got := HashBytes(testBytes(n)); got.String() != fields[1] {
+			t.Errorf("length %d: HashBytes = %s, expected %s", n, got, fields[1])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	for n := 0; n <= 600; n++ {
+		if !seen[n] {
+			t.Fatalf("no vector for length %d", n)
+		}
+	}
+}
+
+func TestExp5InPlace(t *testing.T) {
+	x := new(big.Int).Sub(q, big.NewInt(2))
+	want := new(big.Int).Exp(x, big5int, q)
+
+	if got := exp5(x); got != x || x.Cmp(want) != 0 {
+		t.Fatalf("exp5 returned %p (%s), expected the argument %p with %s", got, got, x, want)
+	}
+
+	for i := 0; i < 100; i++ { // об'єкти, які повертаються в пул, не повинні бути результатом exp5
+		if pooled := bigIntPool.Get().(*big.Int); pooled == x {
+			t.Fatalf("exp5 result was returned to the pool")
+		} else {
+			defer bigIntPool.Put(pooled)
+		}
+	}
+}
+
+// TestHashConcurrent - стрес-тест одночасного гешування з багатьох горутин (запускати з -race):
+// кожен результат порівнюється з гешем бібліотеки iden3
+func TestHashConcurrent(t *testing.T) {
+	const goroutines = 64
+
+	inputs := make([][]*big.Int, INPUTS)
+	want := make([]*big.Int, INPUTS)
+
+	for n := range inputs {
+		inputs[n] = make([]*big.Int, n+1)
+		for i := range inputs[n] {
+			inputs[n][i] = new(big.Int).Sub(q, big.NewInt(int64(n*INPUTS+i+1)))
+		}
+		want[n], _ = poseidon.Hash(inputs[n])
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, goroutines)
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+
+			for k := 0; k < INPUTS; k++ {
+				n := (g + k) % INPUTS
+				if got := Hash(inputs[n]); got.Cmp(want[n]) != 0 {
+					errs <- fmt.Sprintf("goroutine %d, %d inputs: %s != %s", g, n+1, got, want[n])
+					return
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+// fieldState - вектор стану перестановки для property-тестів з testing/quick: ширина 2..17,
+// елементи - випадкові елементи поля або граничні значення 0, 1, q-1
+type fieldState []*big.Int
+
+// Generate - функція генерації випадкового стану (реалізує quick.Generator)
+func (fieldState) Generate(rnd *rand.Rand, _ int) reflect.Value {
+	edges := []*big.Int{big.NewInt(0), big.NewInt(1), new(big.Int).Sub(q, big.NewInt(1))}
+
+	state := make(fieldState, 2+rnd.Intn(INPUTS))
+	for i := range state {
+		if rnd.Intn(4) == 0 {
+			state[i] = new(big.Int).Set(edges[rnd.Intn(len(edges))])
+		} else {
+			state[i] = new(big.Int).Rand(rnd, q)
+		}
+	}
+
+	return reflect.ValueOf(state)
+}
+
+// clone - функція глибокого копіювання стану
+func (s fieldState) clone() []*big.Int {
+	out := make([]*big.Int, len(s))
+	for i, x := range s {
+		out[i] = new(big.Int).Set(x)
+	}
+
+	return out
+}
+
+// canonical - функція перевірки, що всі елементи стану лежать в [0, q)
+func canonical(state []*big.Int) bool {
+	for _, x := range state {
+		if !inField(x) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// quickConfig - налаштування testing/quick з детермінованим генератором
+func quickConfig(count int) *quick.Config {
+	return &quick.Config{MaxCount: count, Rand: rand.New(rand.NewSource(44))}
+}
+
+func TestMixIsMatrixProduct(t *testing.T) {
+	property := func(s fieldState) bool {
+		width := len(s)
+
+		for _, matr := range [][][]*big.Int{c.m[width-2], c.p[width-2]} {
+			got := mix(s.clone(), width, matr)
+
+			for i := range got { // newState[i] = sum_j M[j][i] * state[j] mod q
+				want := new(big.Int)
+				for j := range s {
+					want.Add(want, new(big.Int).Mul(matr[j][i], s[j]))
+				}
+
+				if got[i].Cmp(want.Mod(want, q)) != 0 {
+					return false
+				}
+			}
+
+			if !canonical(got) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, quickConfig(200)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExp5IsFifthPower(t *testing.T) {
+	property := func(s fieldState) bool {
+		state := exp5state(s.clone())
+
+		for i, x := range s {
+			want := new(big.Int).Exp(x, big5int, q)
+
+			if state[i].Cmp(want) != 0 || exp5(new(big.Int).Set(x)).Cmp(want) != 0 {
+				return false
+			}
+		}
+
+		return canonical(state)
+	}
+
+	if err := quick.Check(property, quickConfig(200)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddRoundKeysIsFieldAddition(t *testing.T) {
+	property := func(s fieldState, round uint8) bool {
+		constants := c.c[len(s)-2]
+		r := int(round) % (len(constants) - len(s) + 1)
+
+		state := s.clone()
+		addRoundKeys(state, constants, r)
+
+		for i, x := range s {
+			want := new(big.Int).Add(x, constants[r+i])
+
+			if state[i].Cmp(want.Mod(want, q)) != 0 {
+				return false
+			}
+		}
+
+		return canonical(state)
+	}
+
+	if err := quick.Check(property, quickConfig(200)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// detMod - функція визначника квадратної матриці за модулем q (метод Гаусса над копією матриці)
+func detMod(matrix [][]*big.Int) *big.Int {
+	n := len(matrix)
+	m := make([][]*big.Int, n)
+	for i, row := range matrix {
+		m[i] = fieldState(row).clone()
+	}
+
+	det := big.NewInt(1)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for pivot < n && m[pivot][col].Sign() == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return new(big.Int)
+		}
+		if pivot != col {
+			m[pivot], m[col] = m[col], m[pivot]
+			det.Neg(det)
+		}
+
+		det.Mul(det, m[col][col]).Mod(det, q)
+		inv := new(big.Int).ModInverse(m[col][col], q)
+
+		for i := col + 1; i < n; i++ {
+			f := new(big.Int).Mul(m[i][col], inv)
+			for j := col; j < n; j++ {
+				m[i][j].Sub(m[i][j], new(big.Int).Mul(f, m[col][j])).Mod(m[i][j], q)
+			}
+		}
+	}
+
+	return det
+}
+
+// TestPermuteIsBijection - перестановка є композицією бієкцій: x^5 - бієкція поля, бо gcd(5, q-1) = 1, додавання
+// констант оборотне, а матриці M, P і розріджені матриці часткових раундів мають ненульовий визначник mod q;
+// випадкові стани мають різні канонічні образи
+func TestPermuteIsBijection(t *testing.T) {
+	if new(big.Int).GCD(nil, nil, big5int, new(big.Int).Sub(q, big.NewInt(1))).Cmp(big.NewInt(1)) != 0 {
+		t.Fatal("x^5 is not a bijection of the field")
+	}
+
+	for width := 2; width <= INPUTS+1; width++ {
+		matrices := [][][]*big.Int{c.m[width-2], c.p[width-2]}
+
+		// частковий раунд r: state[0] = sum_j S[r][j] * state[j], state[k] += S[r][t+k-1] * state[0]
+		S := c.s[width-2]
+		for r := 0; r < NROUNDSP[width-2]; r++ {
+			row := S[(2*width-1)*r : (2*width-1)*(r+1)]
+
+			m := make([][]*big.Int, width)
+			m[0] = row[:width]
+			for k := 1; k < width; k++ {
+				m[k] = make([]*big.Int, width)
+				for j := range m[k] {
+					m[k][j] = new(big.Int)
+				}
+				m[k][0], m[k][k] = row[width+k-1], big.NewInt(1)
+			}
+
+			matrices = append(matrices, m)
+		}
+
+		for i, m := range matrices {
+			if detMod(m).Sign() == 0 {
+				t.Fatalf("width %d: matrix %d is singular", width, i)
+			}
+		}
+	}
+
+	images := map[string]string{}
+	property := func(s fieldState) bool {
+		out := permute(s.clone())
+		if !canonical(out) {
+			return false
+		}
+
+		in, image := fmt.Sprint(s), fmt.Sprint(out)
+		if prev, ok := images[image]; ok && prev != in {
+			return false
+		}
+		images[image] = in
+
+		return true
+	}
+
+	if err := quick.Check(property, quickConfig(40)); err != nil {
+		t.Fatal(err)
+	}
+}
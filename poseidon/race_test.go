@@ -0,0 +1,129 @@
+package poseidon
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+// raceCase - входи однієї горутини стрес-тесту і очікувані результати
+type raceCase struct {
+	input    []*big.Int // розділяється з іншими горутинами тієї ж ширини
+	elements []Element
+	msg      []byte
+	hash     *big.Int // poseidon.Hash з iden3
+	bytes    *big.Int // poseidon.HashBytes з iden3
+	mac      *big.Int
+	root     *big.Int
+	neptune  *big.Int // HashWithOptions з VariantNeptune (nil, якщо кількість входів не є арністю Neptune)
+	halo2    *big.Int // hashHalo2 (спільні константи з лінивою ініціалізацією)
+}
+
+// TestConcurrentAPIs - стрес-тест публічних функцій з сотень горутин (запускати з -race): кожна горутина гешує
+// розділені з іншими горутинами входи і порівнює результати з go-iden3-crypto (або з послідовно обчисленими значеннями
+// для функцій без аналога в iden3), після чого перевіряється, що входи не змінилися
+func TestConcurrentAPIs(t *testing.T) {
+	goroutines := 256
+	if testing.Short() {
+		goroutines = 32
+	}
+
+	key := big.NewInt(42)
+
+	inputs := make([][]*big.Int, INPUTS) // по одному розділеному входу на кожну ширину
+	snapshot := make([][]*big.Int, INPUTS)
+	for n := range inputs {
+		for i := 0; i <= n; i++ {
+			x := new(big.Int).Sub(q, big.NewInt(int64(n*31+i+1)))
+			inputs[n] = append(inputs[n], x)
+			snapshot[n] = append(snapshot[n], new(big.Int).Set(x))
+		}
+	}
+
+	cases := make([]raceCase, goroutines)
+
+	for g := range cases {
+		tc := &cases[g]
+		tc.input = inputs[g%INPUTS]
+		tc.msg = testBytes(g*37%700 + 1) // iden3 HashBytes повертає nil для порожнього повідомлення
+
+		for _, x := range tc.input {
+			e, _ := NewElement(x)
+			tc.elements = append(tc.elements, e)
+		}
+
+		var err error
+		if tc.hash, err = poseidon.Hash(tc.input); err != nil {
+			t.Fatal(err)
+		}
+		if tc.bytes, err = poseidon.HashBytes(tc.msg); err != nil {
+			t.Fatal(err)
+		}
+
+		tc.mac, _ = MAC(key, tc.input)
+		tc.root, _ = MerkleRoot(tc.input, 2)
+		tc.neptune, _ = HashWithOptions(tc.input, HashOptions{Variant: VariantNeptune})
+		tc.halo2, _ = hashHalo2(tc.input)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines)
+
+	check := func(g int, name string, got, want *big.Int, err error) bool {
+		if err != nil || got.Cmp(want) != 0 {
+			errs <- fmt.Errorf("goroutine %d: %s returned %v, %v; expected %s", g, name, got, err, want)
+			return false
+		}
+		return true
+	}
+
+	for g := range cases {
+		wg.Add(1)
+		go func(g int, tc *raceCase) {
+			defer wg.Done()
+
+			h := NewHasher() // Hasher - по одному на горутину
+
+			hashed, err := h.Hash(nil, tc.input)
+			digest, ctErr := HashConstantTime(tc.elements...)
+			mac, macErr := MAC(key, tc.input)
+			root, rootErr := MerkleRoot(tc.input, 2)
+			halo2, halo2Err := hashHalo2(tc.input)
+
+			_ = check(g, "Hash", Hash(tc.input), tc.hash, nil) &&
+				check(g, "HashBytes", HashBytes(tc.msg), tc.bytes, nil) &&
+				check(g, "Hasher.Hash", hashed, tc.hash, err) &&
+				check(g, "HashConstantTime", digest.BigInt(), tc.hash, ctErr) &&
+				check(g, "MAC", mac, tc.mac, macErr) &&
+				check(g, "MerkleRoot", root, tc.root, rootErr) &&
+				check(g, "HashWithOptions", halo2, tc.halo2, halo2Err)
+
+			if tc.neptune != nil {
+				neptune, err := HashWithOptions(tc.input, HashOptions{Variant: VariantNeptune})
+				check(g, "HashWithOptions", neptune, tc.neptune, err)
+			}
+
+			if !VerifyMAC(key, tc.input, tc.mac) {
+				errs <- fmt.Errorf("goroutine %d: VerifyMAC rejected a valid tag", g)
+			}
+		}(g, &cases[g])
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	for n := range inputs {
+		for i := range inputs[n] {
+			if inputs[n][i].Cmp(snapshot[n][i]) != 0 {
+				t.Fatalf("shared input %d of width %d was modified", i, n+2)
+			}
+		}
+	}
+}
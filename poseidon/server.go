@@ -0,0 +1,408 @@
+//go:build !(js && wasm)
+
+package poseidon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Обмеження сервера за замовчуванням
+const (
+	defaultMaxBody  = 1 << 20 // максимальний розмір тіла запиту в байтах
+	defaultMaxBatch = 1024    // максимальна кількість запитів в /v1/batch
+)
+
+// hashRequest - тіло запиту /v1/hash і /v1/hash-bytes (а також елемент запиту /v1/batch)
+type hashRequest struct {
+	Elements []string `json:"elements,omitempty"` // елементи поля в десятковому вигляді або з префіксом 0x
+	Data     []byte   `json:"data,omitempty"`     // масив байтів у base64
+	Strict   bool     `json:"strict,omitempty"`   // гешувати байти з HashBytesStrict
+}
+
+// hashResponse - відповідь з гешем або помилкою
+type hashResponse struct {
+	Hash  string `json:"hash,omitempty"` // геш у десятковому вигляді
+	Hex   string `json:"hex,omitempty"`  // геш у шістнадцятковому вигляді з префіксом 0x
+	Error string `json:"error,omitempty"`
+}
+
+type batchRequest struct {
+	Requests []hashRequest `json:"requests"`
+}
+
+type batchResponse struct {
+	Results []hashResponse `json:"results"`
+}
+
+// metrics - лічильники сервера, які віддаються на /metrics у текстовому форматі Prometheus
+type metrics struct {
+	mu        sync.Mutex
+	requests  map[[2]string]uint64  // кількість запитів за (шлях, код відповіді)
+	durations map[string][2]float64 // сума тривалостей (секунди) і кількість запитів за шляхом
+	hashes    map[string]uint64     // кількість обчислених гешів за типом входу
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		requests:  make(map[[2]string]uint64),
+		durations: make(map[string][2]float64),
+		hashes:    make(map[string]uint64),
+	}
+}
+
+func (m *metrics) observe(path string, code int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[[2]string{path, fmt.Sprint(code)}]++
+	sum := m.durations[path]
+	m.durations[path] = [2]float64{sum[0] + d.Seconds(), sum[1] + 1}
+}
+
+func (m *metrics) countHash(kind string) {
+	m.mu.Lock()
+	m.hashes[kind]++
+	m.mu.Unlock()
+}
+
+// writeTo - функція виводу метрик у текстовому форматі Prometheus (рядки відсортовані для стабільного виводу)
+func (m *metrics) writeTo(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var lines []string
+
+	fmt.Fprintln(w, "# HELP poseidond_requests_total Number of HTTP requests by path and status code.")
+	fmt.Fprintln(w, "# TYPE poseidond_requests_total counter")
+	for k, v := range m.requests {
+		lines = append(lines, fmt.Sprintf("poseidond_requests_total{path=%q,code=%q} %d", k[0], k[1], v))
+	}
+	writeSorted(w, lines)
+
+	lines = nil
+	fmt.Fprintln(w, "# HELP poseidond_request_duration_seconds Time spent serving HTTP requests by path.")
+	fmt.Fprintln(w, "# TYPE poseidond_request_duration_seconds summary")
+	for path, d := range m.durations {
+		lines = append(lines,
+			fmt.Sprintf("poseidond_request_duration_seconds_sum{path=%q} %g", path, d[0]),
+			fmt.Sprintf("poseidond_request_duration_seconds_count{path=%q} %g", path, d[1]))
+	}
+	writeSorted(w, lines)
+
+	lines = nil
+	fmt.Fprintln(w, "# HELP poseidond_hashes_total Number of computed Poseidon hashes by input kind.")
+	fmt.Fprintln(w, "# TYPE poseidond_hashes_total counter")
+	for kind, v := range m.hashes {
+		lines = append(lines, fmt.Sprintf("poseidond_hashes_total{kind=%q} %d", kind, v))
+	}
+	writeSorted(w, lines)
+}
+
+func writeSorted(w io.Writer, lines []string) {
+	sort.Strings(lines)
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+}
+
+// server - HTTP/JSON сервіс гешування
+type server struct {
+	maxBody  int64
+	maxBatch int
+	metrics  *metrics
+}
+
+func newServer(maxBody int64, maxBatch int) *server {
+	return &server{maxBody: maxBody, maxBatch: maxBatch, metrics: newMetrics()}
+}
+
+// statusWriter - обгортка http.ResponseWriter, яка запам'ятовує код відповіді для метрик
+type statusWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.code = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// handler - функція побудови маршрутизатора сервера
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/hash", s.post(s.handleHash))
+	mux.HandleFunc("/v1/hash-bytes", s.post(s.handleHashBytes))
+	mux.HandleFunc("/v1/batch", s.post(s.handleBatch))
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		s.metrics.writeTo(w)
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+
+	return mux
+}
+
+// post - функція обгортки JSON-обробника: перевіряє метод, обмежує розмір тіла, декодує запит і рахує метрики
+func (s *server) post(handle func(body []byte) (interface{}, int)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, code: http.StatusOK}
+		defer func() { s.metrics.observe(r.URL.Path, sw.code, time.Since(start)) }()
+
+		if r.Method != http.MethodPost {
+			sw.Header().Set("Allow", http.MethodPost)
+			writeJSON(sw, http.StatusMethodNotAllowed, hashResponse{Error: "method not allowed"})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(sw, r.Body, s.maxBody))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeJSON(sw, http.StatusRequestEntityTooLarge, hashResponse{Error: fmt.Sprintf("request body exceeds %d bytes", s.maxBody)})
+			} else { // обірване з'єднання, неправильне chunked-кодування тощо
+				writeJSON(sw, http.StatusBadRequest, hashResponse{Error: fmt.Sprintf("cannot read request body: %v", err)})
+			}
+			return
+		}
+
+		resp, code := handle(body)
+		writeJSON(sw, code, resp)
+	}
+}
+
+func (s *server) handleHash(body []byte) (interface{}, int) {
+	var req hashRequest
+	if err := decodeJSON(body, &req); err != nil {
+		return hashResponse{Error: err.Error()}, http.StatusBadRequest
+	}
+
+	resp := s.hashElements(req)
+	if resp.Error != "" {
+		return resp, http.StatusBadRequest
+	}
+
+	return resp, http.StatusOK
+}
+
+func (s *server) handleHashBytes(body []byte) (interface{}, int) {
+	var req hashRequest
+	if err := decodeJSON(body, &req); err != nil {
+		return hashResponse{Error: err.Error()}, http.StatusBadRequest
+	}
+
+	if len(req.Elements) != 0 {
+		return hashResponse{Error: "elements are not accepted by /v1/hash-bytes"}, http.StatusBadRequest
+	}
+
+	return s.hashBytes(req), http.StatusOK
+}
+
+func (s *server) handleBatch(body []byte) (interface{}, int) {
+	var req batchRequest
+	if err := decodeJSON(body, &req); err != nil {
+		return hashResponse{Error: err.Error()}, http.StatusBadRequest
+	}
+
+	if len(req.Requests) == 0 || len(req.Requests) > s.maxBatch {
+		return hashResponse{Error: fmt.Sprintf("batch must contain 1..%d requests", s.maxBatch)}, http.StatusBadRequest
+	}
+
+	resp := batchResponse{Results: make([]hashResponse, len(req.Requests))}
+
+	for i, r := range req.Requests { // помилка в одному запиті не зупиняє інші
+		if len(r.Elements) != 0 {
+			resp.Results[i] = s.hashElements(r)
+		} else {
+			resp.Results[i] = s.hashBytes(r)
+		}
+	}
+
+	return resp, http.StatusOK
+}
+
+// hashElements - функція гешування елементів поля із запиту
+func (s *server) hashElements(req hashRequest) hashResponse {
+	if req.Data != nil || req.Strict {
+		return hashResponse{Error: "request must contain either elements or data"}
+	}
+
+	if len(req.Elements) == 0 || len(req.Elements) > INPUTS {
+		return hashResponse{Error: fmt.Sprintf("expected 1..%d elements, got %d", INPUTS, len(req.Elements))}
+	}
+
+	input := make([]*big.Int, len(req.Elements))
+
+	for i, e := range req.Elements {
+		x, err := parseElement(e)
+		if err != nil {
+			return hashResponse{Error: fmt.Sprintf("element %d: %v", i, err)}
+		}
+		input[i] = x
+	}
+
+	s.metrics.countHash("elements")
+
+	return newHashResponse(Hash(input))
+}
+
+// hashBytes - функція гешування масиву байтів із запиту
+func (s *server) hashBytes(req hashRequest) hashResponse {
+	s.metrics.countHash("bytes")
+
+	if req.Strict {
+		return newHashResponse(HashBytesStrict(req.Data))
+	}
+
+	return newHashResponse(HashBytes(req.Data))
+}
+
+func newHashResponse(hash *big.Int) hashResponse {
+	return hashResponse{Hash: hash.String(), Hex: formatDigest(hash, true)}
+}
+
+// decodeJSON - функція декодування тіла запиту з забороною невідомих полів
+func decodeJSON(body []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	if dec.More() {
+		return errors.New("invalid JSON: unexpected data after the request object")
+	}
+
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// serve - функція обслуговування запитів на listener до скасування ctx;
+// після скасування сервер перестає приймати з'єднання і чекає завершення активних запитів не довше shutdownTimeout
+func serve(ctx context.Context, ln net.Listener, handler http.Handler, shutdownTimeout time.Duration) error {
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// RunServe - функція команди serve (poseidon serve, poseidond); з --grpc-addr разом з HTTP/JSON API запускається
+// gRPC-сервіс PoseidonService. Сервіс працює до скасування ctx або до сигналу SIGINT/SIGTERM, після чого плавно
+// завершується; повертає код завершення (0 - успіх, 1 - помилка сервера, 2 - неправильні аргументи).
+func RunServe(ctx context.Context, args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	addr := fs.String("addr", ":8080", "listen address")
+	grpcAddr := fs.String("grpc-addr", "", "gRPC listen address (disabled if empty)")
+	maxBody := fs.Int64("max-body", defaultMaxBody, "maximum request body size in bytes")
+	maxBatch := fs.Int("max-batch", defaultMaxBatch, "maximum number of requests in /v1/batch and BatchHash")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 10*time.Second, "time to wait for active requests on shutdown")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 0 || *maxBody <= 0 || *maxBatch <= 0 {
+		fmt.Fprintf(stderr, "poseidon: invalid serve arguments\n%s", usage)
+		return 2
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintf(stderr, "poseidon: %v\n", err)
+		return 1
+	}
+
+	var grpcLn net.Listener
+	if *grpcAddr != "" {
+		if grpcLn, err = net.Listen("tcp", *grpcAddr); err != nil {
+			ln.Close()
+			fmt.Fprintf(stderr, "poseidon: %v\n", err)
+			return 1
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(ctx) // помилка одного з серверів зупиняє інший
+	defer cancel()
+
+	logger := log.New(stderr, "poseidond ", log.LstdFlags)
+	logger.Printf("listening on %s", ln.Addr())
+
+	errCh := make(chan error, 2)
+	servers := 1
+
+	go func() { errCh <- serve(ctx, ln, newServer(*maxBody, *maxBatch).handler(), *shutdownTimeout) }()
+
+	if grpcLn != nil {
+		logger.Printf("serving gRPC on %s", grpcLn.Addr())
+		servers++
+
+		srv := newGRPCServer(*maxBatch, defaultMaxLeaves).register()
+		go func() { errCh <- serveGRPC(ctx, grpcLn, srv, *shutdownTimeout) }()
+	}
+
+	status := 0
+
+	for i := 0; i < servers; i++ {
+		if err := <-errCh; err != nil {
+			logger.Printf("server error: %v", err)
+			status = 1
+			cancel()
+		}
+	}
+
+	logger.Printf("shut down")
+
+	return status
+}
@@ -0,0 +1,201 @@
+//go:build !(js && wasm)
+
+package poseidon
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// postJSON - функція надсилання JSON-запиту на тестовий сервер; повертає код відповіді і декодоване тіло
+func postJSON(t *testing.T, url, body string, resp interface{}) int {
+	r, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Body.Close()
+
+	if err := json.NewDecoder(r.Body).Decode(resp); err != nil {
+		t.Fatal(err)
+	}
+
+	return r.StatusCode
+}
+
+func TestServerHash(t *testing.T) {
+	ts := httptest.NewServer(newServer(defaultMaxBody, defaultMaxBatch).handler())
+	defer ts.Close()
+
+	want := Hash([]*big.Int{big.NewInt(1), big.NewInt(2)})
+
+	var resp hashResponse
+	if code := postJSON(t, ts.URL+"/v1/hash", `{"elements": ["1", "0x2"]}`, &resp); code != http.StatusOK {
+		t.Fatalf("status %d: %+v", code, resp)
+	}
+
+	if resp.Hash != want.String() || resp.Hex != formatDigest(want, true) {
+		t.Fatalf("response is %+v, expected %s", resp, want)
+	}
+
+	for _, body := range []string{
+		`{"elements": []}`,
+		`{"elements": ["1", "x"]}`,
+		`{"elements": ["` + q.String() + `"]}`,
+		`{"elements": ["1"], "data": "AA=="}`,
+		`{"elements": ["1"], "unknown": 1}`,
+		`{"elements": [1]}`,
+		`not json`,
+	} {
+		resp = hashResponse{}
+		if code := postJSON(t, ts.URL+"/v1/hash", body, &resp); code != http.StatusBadRequest || resp.Error == "" {
+			t.Fatalf("%s: status %d, response %+v", body, code, resp)
+		}
+	}
+}
+
+func TestServerHashBytes(t *testing.T) {
+	ts := httptest.NewServer(newServer(defaultMaxBody, defaultMaxBatch).handler())
+	defer ts.Close()
+
+	var resp hashResponse
+	if code := postJSON(t, ts.URL+"/v1/hash-bytes", `{"data": "aGVsbG8gd29ybGQ="}`, &resp); code != http.StatusOK {
+		t.Fatalf("status %d: %+v", code, resp)
+	}
+
+	if want := HashBytes([]byte("hello world")); resp.Hash != want.String() {
+		t.Fatalf("hash is %s, expected %s", resp.Hash, want)
+	}
+
+	postJSON(t, ts.URL+"/v1/hash-bytes", `{"data": "aGVsbG8gd29ybGQ=", "strict": true}`, &resp)
+	if want := HashBytesStrict([]byte("hello world")); resp.Hash != want.String() {
+		t.Fatalf("strict hash is %s, expected %s", resp.Hash, want)
+	}
+
+	r, err := http.Get(ts.URL + "/v1/hash-bytes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Body.Close()
+
+	if r.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("GET returned status %d", r.StatusCode)
+	}
+}
+
+func TestServerBatch(t *testing.T) {
+	ts := httptest.NewServer(newServer(defaultMaxBody, 3).handler())
+	defer ts.Close()
+
+	var resp batchResponse
+	body := `{"requests": [{"elements": ["1", "2"]}, {"data": "YWJj"}, {"elements": ["-1"]}]}`
+	if code := postJSON(t, ts.URL+"/v1/batch", body, &resp); code != http.StatusOK {
+		t.Fatalf("status %d", code)
+	}
+
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(resp.Results))
+	}
+
+	if want := Hash([]*big.Int{big.NewInt(1), big.NewInt(2)}); resp.Results[0].Hash != want.String() {
+		t.Fatalf("first result is %+v, expected %s", resp.Results[0], want)
+	}
+
+	if want := HashBytes([]byte("abc")); resp.Results[1].Hash != want.String() {
+		t.Fatalf("second result is %+v, expected %s", resp.Results[1], want)
+	}
+
+	if resp.Results[2].Error == "" || resp.Results[2].Hash != "" {
+		t.Fatalf("invalid request in batch returned %+v", resp.Results[2])
+	}
+
+	var errResp hashResponse
+	body = `{"requests": [{}, {}, {}, {}]}`
+	if code := postJSON(t, ts.URL+"/v1/batch", body, &errResp); code != http.StatusBadRequest {
+		t.Fatalf("oversized batch returned status %d", code)
+	}
+}
+
+func TestServerLimitsAndMetrics(t *testing.T) {
+	ts := httptest.NewServer(newServer(64, defaultMaxBatch).handler())
+	defer ts.Close()
+
+	var resp hashResponse
+	body := `{"data": "` + strings.Repeat("A", 100) + `"}`
+	if code := postJSON(t, ts.URL+"/v1/hash-bytes", body, &resp); code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("oversized body returned status %d", code)
+	}
+
+	postJSON(t, ts.URL+"/v1/hash", `{"elements": ["1"]}`, &resp)
+
+	r, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Body.Close()
+
+	out, _ := io.ReadAll(r.Body)
+
+	for _, line := range []string{
+		`# TYPE poseidond_requests_total counter`,
+		`poseidond_requests_total{path="/v1/hash",code="200"} 1`,
+		`poseidond_requests_total{path="/v1/hash-bytes",code="413"} 1`,
+		`poseidond_request_duration_seconds_count{path="/v1/hash"} 1`,
+		`poseidond_hashes_total{kind="elements"} 1`,
+	} {
+		if !strings.Contains(string(out), line+"\n") {
+			t.Fatalf("metrics do not contain %q:\n%s", line, out)
+		}
+	}
+}
+
+// failingBody - тіло запиту, читання якого завершується помилкою (як обірване з'єднання)
+type failingBody struct{}
+
+func (failingBody) Read([]byte) (int, error) { return 0, io.ErrUnexpectedEOF }
+
+func TestServerBodyReadError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/hash", failingBody{})
+
+	newServer(defaultMaxBody, defaultMaxBatch).handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("body read error returned status %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestServeGracefulShutdown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() { done <- serve(ctx, ln, newServer(defaultMaxBody, defaultMaxBatch).handler(), time.Second) }()
+
+	var resp hashResponse
+	if code := postJSON(t, "http://"+ln.Addr().String()+"/v1/hash", `{"elements": ["1"]}`, &resp); code != http.StatusOK {
+		t.Fatalf("status %d", code)
+	}
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("serve returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("server did not shut down")
+	}
+}
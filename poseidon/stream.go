@@ -0,0 +1,82 @@
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+)
+
+var ErrLengthMismatch = errors.New("poseidon: written data does not match the declared length")
+
+// BytesHasher - потоковий гешер масиву байтів (реалізує io.Writer): дані можна записувати частинами довільного розміру,
+// а пам'ять не залежить від довжини повідомлення. Sum від гешера з NewBytesHasher збігається з HashBytes від конкатенації
+// записаних даних, а від гешера з NewStrictBytesHasher - з HashBytesStrict.
+type BytesHasher struct {
+	sponge  *sponge
+	block   [SBLOCK]byte // неповний блок повідомлення, який ще не поглинутий
+	n       int          // кількість байтів у block
+	strict  bool         // кодування PaddingStrict замість legacy
+	length  uint64       // оголошена довжина повідомлення (лише для strict)
+	written uint64       // кількість записаних байтів
+}
+
+// NewBytesHasher - функція створення потокового гешера з legacy-кодуванням HashBytes
+func NewBytesHasher() *BytesHasher {
+	return &BytesHasher{sponge: newSponge()}
+}
+
+// NewStrictBytesHasher - функція створення потокового гешера з кодуванням HashBytesStrict. Оскільки довжина повідомлення
+// поглинається першою, її потрібно знати наперед; Write і Sum повертають ErrLengthMismatch, якщо записано інше число байтів.
+func NewStrictBytesHasher(length uint64) *BytesHasher {
+	h := &BytesHasher{sponge: newSponge(), strict: true, length: length}
+	h.sponge.absorb(strictLength(length))
+
+	return h
+}
+
+// Write - функція запису наступної частини повідомлення
+func (h *BytesHasher) Write(p []byte) (int, error) {
+	if h.strict && uint64(len(p)) > h.length-h.written {
+		return 0, ErrLengthMismatch
+	}
+
+	for i := range p {
+		h.block[h.n] = p[i]
+		h.n++
+
+		if h.n == SBLOCK { // блок заповнений - поглинаємо його як big-endian елемент
+			h.sponge.absorb(new(big.Int).SetBytes(h.block[:]))
+			h.n = 0
+		}
+	}
+
+	h.written += uint64(len(p))
+
+	return len(p), nil
+}
+
+// Sum - функція обчислення гешу записаних даних; стан гешера не змінюється, тому запис можна продовжити
+func (h *BytesHasher) Sum() (*big.Int, error) {
+	if h.strict && h.written != h.length {
+		return nil, ErrLengthMismatch
+	}
+
+	var last [SBLOCK]byte // останній блок, доповнений нулями (і маркером 10* для strict)
+	copy(last[:], h.block[:h.n])
+
+	if h.strict {
+		last[h.n] = 0x80
+	} else if h.n == 0 { // legacy-кодування не додає блок, якщо повідомлення закінчилося на межі блоку
+		return h.sponge.sum(), nil
+	}
+
+	// поглинаємо останній блок в копію губки, щоб не змінювати стан гешера
+	s := newSponge()
+	for j := range s.inputs {
+		s.inputs[j].Set(h.sponge.inputs[j])
+	}
+	s.hash, s.dirty, s.k = h.sponge.hash, h.sponge.dirty, h.sponge.k
+
+	s.absorb(new(big.Int).SetBytes(last[:]))
+
+	return s.sum(), nil
+}
@@ -0,0 +1,63 @@
+package poseidon
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBytesHasherMatchesHashBytes(t *testing.T) {
+	for _, n := range []int{0, 1, 30, 31, 32, 62, 496, 497, 1000} {
+		msg := testBytes(n)
+
+		for _, chunk := range []int{1, 7, 31, 64, 1000} {
+			h := NewBytesHasher()
+			s := NewStrictBytesHasher(uint64(n))
+
+			for i := 0; i < n; i += chunk {
+				h.Write(msg[i:minInt(n, i+chunk)])
+				if _, err := s.Write(msg[i:minInt(n, i+chunk)]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if sum, _ := h.Sum(); sum.Cmp(HashBytes(msg)) != 0 {
+				t.Fatalf("length %d, chunk %d: streamed hash differs from HashBytes", n, chunk)
+			}
+
+			if sum, err := s.Sum(); err != nil || sum.Cmp(HashBytesStrict(msg)) != 0 {
+				t.Fatalf("length %d, chunk %d: streamed hash differs from HashBytesStrict (%v)", n, chunk, err)
+			}
+		}
+	}
+}
+
+func TestBytesHasherSumDoesNotChangeState(t *testing.T) {
+	msg := testBytes(600)
+
+	h := NewBytesHasher()
+	h.Write(msg[:300])
+
+	if sum, _ := h.Sum(); sum.Cmp(HashBytes(msg[:300])) != 0 {
+		t.Fatalf("intermediate sum differs from HashBytes of the prefix")
+	}
+
+	h.Write(msg[300:])
+
+	if sum, _ := h.Sum(); sum.Cmp(HashBytes(msg)) != 0 {
+		t.Fatalf("sum after continued writes differs from HashBytes")
+	}
+}
+
+func TestStrictBytesHasherLength(t *testing.T) {
+	h := NewStrictBytesHasher(5)
+
+	if _, err := h.Write(make([]byte, 6)); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("writing past the declared length returned %v", err)
+	}
+
+	h.Write(make([]byte, 3))
+
+	if _, err := h.Sum(); !errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("sum before the declared length returned %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package poseidon
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+var (
+	ErrUnsupportedType = errors.New("poseidon: unsupported type")
+	ErrNilValue        = errors.New("poseidon: nil value")
+	ErrInvalidTag      = errors.New("poseidon: invalid struct tag")
+	ErrCyclicValue     = errors.New("poseidon: cyclic value")
+)
+
+var bigIntType = reflect.TypeOf(big.Int{})
+
+// HashStruct - функція гешування довільного значення Go (як правило, структури) через канонічне кодування в елементи поля:
+//   - bool - 0 або 1; цілі числа - їх значення за модулем q (від'ємне x кодується як q-|x|);
+//   - big.Int і *big.Int - саме значення, яке повинно бути в межах 0 <= x < q;
+//   - string, []byte і [N]byte - HashBytesStrict від байтів;
+//   - масиви, зрізи і структури - список елементів [n, e1, ..., en], який поглинається губкою absorbElements;
+//   - вказівники - значення, на яке вони вказують (nil - помилка ErrNilValue); якщо значення через вказівники чи зрізи
+//     посилається само на себе, повертається ErrCyclicValue.
+//
+// Поля структури кодуються в порядку оголошення; тег `poseidon:"-"` пропускає поле, а тег `poseidon:"N"` задає позицію
+// поля явно (тоді позиції повинні мати всі поля структури, які не пропускаються), щоб геш не залежав від порядку полів у коді.
+// Неекспортовані поля пропускаються. Карти, числа з плаваючою комою, канали, функції та поля й елементи інтерфейсного
+// типу (їх кодування не залежало б від динамічного типу) не підтримуються.
+func HashStruct(v interface{}) (*big.Int, error) {
+	return encodeValue(reflect.ValueOf(v), map[visit]bool{})
+}
+
+// visit - вказівник або зріз, кодування якого ще не завершене (для виявлення циклів)
+type visit struct {
+	ptr uintptr
+	len int // довжина зрізу: порожній зріз s[:0] має ту саму адресу, що й s, але не утворює циклу
+	typ reflect.Type
+}
+
+// encodeValue - функція канонічного кодування значення v в один елемент поля; visiting - вказівники і зрізи на шляху
+// від кореня до v
+func encodeValue(v reflect.Value, visiting map[visit]bool) (*big.Int, error) {
+	if !v.IsValid() {
+		return nil, ErrNilValue
+	}
+
+	if v.Type() == bigIntType {
+		x := v.Interface().(big.Int)
+		if !inField(&x) {
+			return nil, ErrInvalidInput
+		}
+
+		return new(big.Int).Set(&x), nil
+	}
+
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Slice) && !v.IsNil() {
+		key := visit{ptr: v.Pointer(), typ: v.Type()}
+		if v.Kind() == reflect.Slice {
+			key.len = v.Len()
+		}
+
+		if visiting[key] {
+			return nil, fmt.Errorf("%w: %s", ErrCyclicValue, v.Type())
+		}
+
+		visiting[key] = true
+		defer delete(visiting, key)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil, ErrNilValue
+		}
+
+		return encodeValue(v.Elem(), visiting)
+	case reflect.Bool:
+		if v.Bool() {
+			return big.NewInt(1), nil
+		}
+
+		return big.NewInt(0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x := big.NewInt(v.Int())
+
+		return x.Mod(x, q), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(v.Uint()), nil
+	case reflect.String:
+		return HashBytesStrict([]byte(v.String())), nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 { // []byte і [N]byte гешуються як масив байтів
+			buf := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(buf), v)
+
+			return HashBytesStrict(buf), nil
+		}
+
+		elems := make([]*big.Int, v.Len())
+		for i := range elems {
+			e, err := encodeValue(v.Index(i), visiting)
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = e
+		}
+
+		return hashList(elems), nil
+	case reflect.Struct:
+		return encodeStruct(v, visiting)
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+}
+
+// encodeStruct - функція кодування полів структури в порядку, заданому тегами poseidon
+func encodeStruct(v reflect.Value, visiting map[visit]bool) (*big.Int, error) {
+	type field struct {
+		pos   int
+		value reflect.Value
+	}
+
+	var fields []field
+
+	tagged := 0
+
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.PkgPath != "" { // неекспортоване поле
+			continue
+		}
+
+		tag, ok := f.Tag.Lookup("poseidon")
+		if tag == "-" {
+			continue
+		}
+
+		pos := len(fields)
+		if ok {
+			n, err := strconv.Atoi(tag)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("%w: field %s: %q", ErrInvalidTag, f.Name, tag)
+			}
+			pos = n
+			tagged++
+		}
+
+		fields = append(fields, field{pos: pos, value: v.Field(i)})
+	}
+
+	if tagged != 0 && tagged != len(fields) {
+		return nil, fmt.Errorf("%w: %s mixes positioned and unpositioned fields", ErrInvalidTag, v.Type())
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].pos < fields[j].pos })
+
+	elems := make([]*big.Int, len(fields))
+	for i, f := range fields {
+		if i > 0 && fields[i-1].pos == f.pos {
+			return nil, fmt.Errorf("%w: %s has duplicate position %d", ErrInvalidTag, v.Type(), f.pos)
+		}
+
+		e, err := encodeValue(f.value, visiting)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = e
+	}
+
+	return hashList(elems), nil
+}
+
+// hashList - функція гешування списку елементів разом з його довжиною
+func hashList(elems []*big.Int) *big.Int {
+	return absorbElements(append([]*big.Int{big.NewInt(int64(len(elems)))}, elems...))
+}
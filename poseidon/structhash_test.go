@@ -0,0 +1,210 @@
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+type testClaim struct {
+	Schema  [16]byte
+	Subject *big.Int
+	Expiry  uint64
+	Revoked bool
+	Name    string
+	Data    []byte
+	Slots   []int64
+	Nested  testNested
+	Comment string `poseidon:"-"`
+	secret  int
+}
+
+type testNested struct {
+	A int
+	B big.Int
+}
+
+type testPositioned struct {
+	B int `poseidon:"1"`
+	A int `poseidon:"0"`
+}
+
+type testOrdered struct {
+	A int
+	B int
+}
+
+func newTestClaim() testClaim {
+	return testClaim{
+		Schema:  [16]byte{1, 2, 3},
+		Subject: big.NewInt(12345),
+		Expiry:  1700000000,
+		Name:    "alice",
+		Data:    []byte{0xde, 0xad},
+		Slots:   []int64{1, -2, 3},
+		Nested:  testNested{A: 5, B: *big.NewInt(6)},
+		Comment: "ignored",
+		secret:  1,
+	}
+}
+
+func TestHashStructDeterministic(t *testing.T) {
+	a, err := HashStruct(newTestClaim())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claim := newTestClaim()
+	b, err := HashStruct(&claim)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Cmp(b) != 0 {
+		t.Fatalf("struct and pointer to struct hash differently: %s != %s", a, b)
+	}
+
+	claim.Comment = "other comment"
+	claim.secret = 2
+	if c, _ := HashStruct(claim); c.Cmp(a) != 0 {
+		t.Fatalf("skipped or unexported fields changed the hash")
+	}
+}
+
+func TestHashStructFieldChanges(t *testing.T) {
+	base, _ := HashStruct(newTestClaim())
+
+	changes := map[string]func(c *testClaim){
+		"schema":  func(c *testClaim) { c.Schema[15] = 1 },
+		"subject": func(c *testClaim) { c.Subject = big.NewInt(12346) },
+		"expiry":  func(c *testClaim) { c.Expiry++ },
+		"revoked": func(c *testClaim) { c.Revoked = true },
+		"name":    func(c *testClaim) { c.Name = "alice\x00" },
+		"data":    func(c *testClaim) { c.Data = append(c.Data, 0) },
+		"slots":   func(c *testClaim) { c.Slots = append(c.Slots, 0) },
+		"nested":  func(c *testClaim) { c.Nested.B.SetInt64(7) },
+	}
+
+	for name, change := range changes {
+		claim := newTestClaim()
+		change(&claim)
+
+		hash, err := HashStruct(claim)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if hash.Cmp(base) == 0 {
+			t.Fatalf("%s: changing the field did not change the hash", name)
+		}
+	}
+}
+
+func TestHashStructEncoding(t *testing.T) {
+	hash, err := HashStruct(testOrdered{A: 1, B: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frame := make([]*big.Int, INPUTS) // кадр губки: [довжина, A, B, 0, ...]
+	for i := range frame {
+		frame[i] = new(big.Int)
+	}
+	frame[0].SetInt64(2)
+	frame[1].SetInt64(1)
+	frame[2].Sub(q, big.NewInt(1))
+
+	if want := Hash(frame); hash.Cmp(want) != 0 {
+		t.Fatalf("struct hash is %s, expected Hash([2, 1, q-1, 0...]) = %s", hash, want)
+	}
+
+	positioned, _ := HashStruct(testPositioned{A: 1, B: -1})
+	if positioned.Cmp(hash) != 0 {
+		t.Fatalf("positioned fields are not ordered by their tags")
+	}
+
+	str, _ := HashStruct("abc")
+	if str.Cmp(HashBytesStrict([]byte("abc"))) != 0 {
+		t.Fatalf("string is not hashed with HashBytesStrict")
+	}
+}
+
+// node - елемент зв'язного списку для перевірки циклів
+type node struct {
+	Value int
+	Next  *node
+}
+
+// nested - зріз, елементами якого є такі самі зрізи
+type nested []nested
+
+func TestHashStructShared(t *testing.T) {
+	// спільні (але не циклічні) вказівники і зрізи дозволені й кодуються як значення
+	shared := big.NewInt(5)
+	pair := struct{ A, B *big.Int }{shared, shared}
+
+	got, err := HashStruct(pair)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, _ := HashStruct(struct{ A, B *big.Int }{big.NewInt(5), big.NewInt(5)}); got.Cmp(want) != 0 {
+		t.Fatalf("shared pointers hash to %s, copies to %s", got, want)
+	}
+
+	s := make(nested, 2)
+	s[0], s[1] = s[:0], s[1:1] // порожні зрізи з адресою s не утворюють циклу
+	if _, err := HashStruct(s); err != nil {
+		t.Fatalf("empty subslices returned %v", err)
+	}
+}
+
+func TestHashStructErrors(t *testing.T) {
+	type withMap struct{ M map[string]int }
+	type withFloat struct{ F float64 }
+	type withNil struct{ P *big.Int }
+	type mixed struct {
+		A int `poseidon:"0"`
+		B int
+	}
+	type duplicate struct {
+		A int `poseidon:"0"`
+		B int `poseidon:"0"`
+	}
+	type badTag struct {
+		A int `poseidon:"first"`
+	}
+	type withInterface struct{ V interface{} }
+
+	loop := &node{Value: 1}
+	loop.Next = &node{Value: 2, Next: loop}
+
+	selfSlice := make(nested, 1)
+	selfSlice[0] = selfSlice
+
+	tests := []struct {
+		name  string
+		value interface{}
+		err   error
+	}{
+		{name: "map", value: withMap{}, err: ErrUnsupportedType},
+		{name: "float", value: withFloat{}, err: ErrUnsupportedType},
+		{name: "nil pointer", value: withNil{}, err: ErrNilValue},
+		{name: "nil", value: nil, err: ErrNilValue},
+		{name: "not in field", value: withNil{P: new(big.Int).Set(q)}, err: ErrInvalidInput},
+		{name: "mixed tags", value: mixed{}, err: ErrInvalidTag},
+		{name: "duplicate position", value: duplicate{}, err: ErrInvalidTag},
+		{name: "bad tag", value: badTag{}, err: ErrInvalidTag},
+		{name: "interface field", value: withInterface{V: 1}, err: ErrUnsupportedType},
+		{name: "nil interface field", value: withInterface{}, err: ErrUnsupportedType},
+		{name: "interface elements", value: []interface{}{1, "a"}, err: ErrUnsupportedType},
+		{name: "pointer cycle", value: loop, err: ErrCyclicValue},
+		{name: "slice cycle", value: selfSlice, err: ErrCyclicValue},
+	}
+
+	for _, tt := range tests {
+		if _, err := HashStruct(tt.value); !errors.Is(err, tt.err) {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.err, err)
+		}
+	}
+}
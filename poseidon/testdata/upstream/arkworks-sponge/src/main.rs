@@ -0,0 +1,58 @@
+//! Prints PoseidonSponge digests over BLS12-381 Fr for TestArkworksUpstreamVectors (compat_test.go):
+//!
+//!     cargo run --release --manifest-path testdata/upstream/arkworks-sponge/Cargo.toml \
+//!         > testdata/upstream/arkworks-sponge.json
+//!
+//! The parameters are the PARAMS_OPT_FOR_CONSTRAINTS defaults of PoseidonDefaultConfig for rates 2..8,
+//! generated with find_poseidon_ark_and_mds (skip_matrices = 0) as in bls12_381_fr_poseidon_default_parameters_test.
+use ark_bls12_381::Fr;
+use ark_crypto_primitives::sponge::poseidon::{find_poseidon_ark_and_mds, PoseidonConfig, PoseidonSponge};
+use ark_crypto_primitives::sponge::CryptographicSponge;
+use ark_ff::PrimeField;
+
+/// (rate, alpha, full_rounds, partial_rounds)
+const PARAMS: [(usize, u64, usize, usize); 7] = [
+    (2, 17, 8, 31),
+    (3, 11, 8, 33),
+    (4, 11, 8, 33),
+    (5, 7, 8, 49),
+    (6, 7, 8, 49),
+    (7, 7, 8, 49),
+    (8, 7, 8, 49),
+];
+
+fn main() {
+    let mut vectors = Vec::new();
+
+    for &(rate, alpha, full_rounds, partial_rounds) in PARAMS.iter() {
+        let (ark, mds) = find_poseidon_ark_and_mds::<Fr>(
+            Fr::MODULUS_BIT_SIZE as u64,
+            rate,
+            full_rounds as u64,
+            partial_rounds as u64,
+            0,
+        );
+        let config = PoseidonConfig::new(full_rounds, partial_rounds, alpha, mds, ark, rate, 1);
+
+        for len in [0, 1, rate, rate + 1, 2 * rate + 1] {
+            let input: Vec<Fr> = (1..=len as u64).map(Fr::from).collect();
+
+            let mut sponge = PoseidonSponge::new(&config);
+            sponge.absorb(&input);
+            let hash = sponge.squeeze_field_elements::<Fr>(1);
+
+            let inputs: Vec<String> = input.iter().map(|x| format!("\"{}\"", x.into_bigint())).collect();
+            vectors.push(format!(
+                "    {{\"profile\": \"arkworks\", \"rate\": {}, \"inputs\": [{}], \"hash\": \"{}\"}}",
+                rate,
+                inputs.join(", "),
+                hash[0].into_bigint()
+            ));
+        }
+    }
+
+    println!(
+        "{{\n  \"source\": \"ark-crypto-primitives 0.4 PoseidonSponge\",\n  \"vectors\": [\n{}\n  ]\n}}",
+        vectors.join(",\n")
+    );
+}
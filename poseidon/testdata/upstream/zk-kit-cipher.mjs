@@ -0,0 +1,25 @@
+// Generates known-answer vectors for Encrypt/Decrypt (cipher.go) with the reference implementation
+// of the Poseidon duplex cipher, @zk-kit/poseidon-cipher. TestCipherUpstreamVectors (go test -tags upstream)
+// checks the output file and fails until it exists:
+//
+//   npm install @zk-kit/poseidon-cipher
+//   node testdata/upstream/zk-kit-cipher.mjs > testdata/upstream/zk-kit-cipher.json
+import { poseidonEncrypt } from "@zk-kit/poseidon-cipher"
+
+const q = 21888242871839275222246405745257275088548364400416034343698204186575808495617n
+
+const cases = [
+  { key: [1n, 2n], nonce: 3n, plaintext: [10n, 20n] },
+  { key: [123n, 456n], nonce: 5n, plaintext: [1n, 8n, 15n] },
+  { key: [123n, 456n], nonce: 5n, plaintext: [1n, 8n, 15n, 22n, 29n, 36n, 43n] },
+  { key: [q - 1n, q - 2n], nonce: 2n ** 128n - 1n, plaintext: [q - 1n, 0n, 1n, 2n] },
+]
+
+const vectors = cases.map(({ key, nonce, plaintext }) => ({
+  key: key.map(String),
+  nonce: String(nonce),
+  plaintext: plaintext.map(String),
+  ciphertext: poseidonEncrypt(plaintext, key, nonce).map(String),
+}))
+
+console.log(JSON.stringify({ source: "@zk-kit/poseidon-cipher poseidonEncrypt", vectors }, null, 2))
@@ -0,0 +1,156 @@
+package poseidon
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math/big"
+	"strconv"
+)
+
+// TraceStep - стан перестановки після одного кроку раунду.
+// В оптимізованій перестановці circomlib раунд r виконує sbox, додавання констант і mix, але константи, додані
+// між sbox і mix раунду r, - це (перетворені матрицею) константи раунду r+1, а константи раунду 0 додаються окремим
+// кроком перед першим S-блоком. Тому Round кроків "sbox" і "mix" - раунд, у якому вони виконуються, а Round кроку
+// "ark" - раунд, константи якого він додає:
+//
+//	ark 0, sbox 0, ark 1, mix 0, sbox 1, ark 2, mix 1, ..., sbox R-2, ark R-1, mix R-2, sbox R-1, mix R-1
+//
+// (R = NROUNDSF+NROUNDSP), тобто кожен раунд має рівно один крок "ark", і він передує S-блоку цього раунду.
+type TraceStep struct {
+	Round   int        // номер раунду 0..NROUNDSF+NROUNDSP-1 (для "ark" - раунд, константи якого додаються)
+	Partial bool       // крок виконується в частковому раунді (S-блок і константа лише для state[0], розріджена матриця S)
+	Op      string     // крок: "ark" (додавання констант), "sbox" (x^5) або "mix" (множення на матрицю)
+	State   []*big.Int // стан після кроку
+}
+
+// Trace - трасування Hash: вхід, параметри перестановки, стани після кожного кроку і результат
+type Trace struct {
+	Input    []*big.Int
+	Width    int // ширина стану (кількість входів + 1)
+	NRoundsF int
+	NRoundsP int
+	Steps    []TraceStep
+	Hash     *big.Int // перший елемент стану після останнього кроку, дорівнює Hash(Input)
+}
+
+// HashTrace - функція гешування з трасуванням: записує стан після кожного addRoundKeys, S-блоку і mix повних і
+// часткових раундів (для налагодження свідків схем і навчання). Стани відповідають оптимізованій перестановці
+// з константами circomlib, тобто тим самим сигналам, що й у poseidon.circom.
+// Повертає ErrInputsLength або ErrInvalidInput для некоректного входу.
+func HashTrace(input []*big.Int) (*Trace, error) {
+	if len(input) == 0 || len(input) > INPUTS {
+		return nil, ErrInputsLength
+	}
+
+	state := make([]*big.Int, len(input)+1)
+	state[0] = big.NewInt(0)
+
+	for i, x := range input {
+		if !inField(x) {
+			return nil, ErrInvalidInput
+		}
+		state[i+1] = new(big.Int).Set(x)
+	}
+
+	tr := &Trace{Input: cloneInts(state[1:]), Width: len(state), NRoundsF: NROUNDSF, NRoundsP: NROUNDSP[len(state)-2]}
+
+	state = permuteTraced(state, func(round int, partial bool, op string, state []*big.Int) {
+		tr.Steps = append(tr.Steps, TraceStep{Round: round, Partial: partial, Op: op, State: cloneInts(state)})
+	})
+	tr.Hash = new(big.Int).Set(state[0])
+
+	return tr, nil
+}
+
+// cloneInts - функція глибокого копіювання масиву елементів
+func cloneInts(values []*big.Int) []*big.Int {
+	out := make([]*big.Int, len(values))
+	for i, x := range values {
+		out[i] = new(big.Int).Set(x)
+	}
+
+	return out
+}
+
+// traceJSON - JSON-представлення трасування: числа записуються десятковими рядками, бо не вміщаються в double
+type traceJSON struct {
+	Input    []string        `json:"input"`
+	Width    int             `json:"width"`
+	NRoundsF int             `json:"nRoundsF"`
+	NRoundsP int             `json:"nRoundsP"`
+	Steps    []traceStepJSON `json:"steps"`
+	Hash     string          `json:"hash"`
+}
+
+type traceStepJSON struct {
+	Round int      `json:"round"`
+	Type  string   `json:"type"` // "full" або "partial"
+	Op    string   `json:"op"`
+	State []string `json:"state"`
+}
+
+// decimalStrings - функція перетворення елементів у десяткові рядки
+func decimalStrings(values []*big.Int) []string {
+	out := make([]string, len(values))
+	for i, x := range values {
+		out[i] = x.String()
+	}
+
+	return out
+}
+
+// roundType - функція назви типу раунду для експорту
+func roundType(partial bool) string {
+	if partial {
+		return "partial"
+	}
+
+	return "full"
+}
+
+// WriteJSON - функція експорту трасування в JSON (елементи - десяткові рядки)
+func (t *Trace) WriteJSON(w io.Writer) error {
+	out := traceJSON{
+		Input:    decimalStrings(t.Input),
+		Width:    t.Width,
+		NRoundsF: t.NRoundsF,
+		NRoundsP: t.NRoundsP,
+		Steps:    make([]traceStepJSON, len(t.Steps)),
+		Hash:     t.Hash.String(),
+	}
+
+	for i, s := range t.Steps {
+		out.Steps[i] = traceStepJSON{Round: s.Round, Type: roundType(s.Partial), Op: s.Op, State: decimalStrings(s.State)}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(out)
+}
+
+// WriteCSV - функція експорту трасування в CSV: заголовок "step,round,type,op,s0,...,s{t-1}" і по рядку на крок
+func (t *Trace) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"step", "round", "type", "op"}
+	for i := 0; i < t.Width; i++ {
+		header = append(header, "s"+strconv.Itoa(i))
+	}
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for i, s := range t.Steps {
+		record := append([]string{strconv.Itoa(i), strconv.Itoa(s.Round), roundType(s.Partial), s.Op}, decimalStrings(s.State)...)
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
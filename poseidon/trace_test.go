@@ -0,0 +1,174 @@
+package poseidon
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestHashTrace(t *testing.T) {
+	for n := 1; n <= INPUTS; n++ {
+		input := make([]*big.Int, n)
+		for i := range input {
+			input[i] = new(big.Int).Sub(q, big.NewInt(int64(i*n+1)))
+		}
+
+		tr, err := HashTrace(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		width, nRoundsP := n+1, NROUNDSP[n-1]
+
+		if want := Hash(input); tr.Hash.Cmp(want) != 0 || tr.Steps[len(tr.Steps)-1].State[0].Cmp(want) != 0 {
+			t.Fatalf("%d inputs: trace hash is %s, Hash is %s", n, tr.Hash, want)
+		}
+
+		// початкове ark, 4 повних раунди, nRoundsP часткових, 3 повних раунди і останній раунд без ark
+		if want := 1 + 3*NROUNDSF/2 + 3*nRoundsP + 3*(NROUNDSF/2-1) + 2; len(tr.Steps) != want {
+			t.Fatalf("%d inputs: %d steps, expected %d", n, len(tr.Steps), want)
+		}
+
+		// послідовність кроків: ark позначений раундом, константи якого він додає, sbox і mix - раундом виконання
+		rounds := NROUNDSF + nRoundsP
+		want := []TraceStep{{Round: 0, Op: "ark"}}
+		for r := 0; r < rounds; r++ {
+			partial := r >= NROUNDSF/2 && r < NROUNDSF/2+nRoundsP
+			want = append(want, TraceStep{Round: r, Partial: partial, Op: "sbox"})
+			if r < rounds-1 {
+				want = append(want, TraceStep{Round: r + 1, Partial: partial, Op: "ark"})
+			}
+			want = append(want, TraceStep{Round: r, Partial: partial, Op: "mix"})
+		}
+
+		for i, step := range tr.Steps {
+			if step.Round != want[i].Round || step.Partial != want[i].Partial || step.Op != want[i].Op {
+				t.Fatalf("%d inputs: step %d is round %d (partial %v) %s, expected round %d (partial %v) %s",
+					n, i, step.Round, step.Partial, step.Op, want[i].Round, want[i].Partial, want[i].Op)
+			}
+		}
+
+		// кроки перевіряються незалежно від permute: S-блок - x^5, повний mix - множення на M (P в середині),
+		// а різниці станів на кроках ark, записані послідовно, мають утворити масив констант C
+		C, M, P := c.c[width-2], c.m[width-2], c.p[width-2]
+		prev := append([]*big.Int{big.NewInt(0)}, input...)
+		var keys []*big.Int
+
+		for i, step := range tr.Steps {
+			partial := step.Partial
+			if len(step.State) != width || !canonical(step.State) {
+				t.Fatalf("%d inputs: malformed step %d (round %d, %s)", n, i, step.Round, step.Op)
+			}
+
+			switch {
+			case step.Op == "ark":
+				for j := range step.State {
+					if !partial || j == 0 {
+						keys = append(keys, new(big.Int).Mod(new(big.Int).Sub(step.State[j], prev[j]), q))
+					}
+				}
+
+			case step.Op == "sbox":
+				for j := range step.State {
+					want := prev[j]
+					if !partial || j == 0 {
+						want = new(big.Int).Exp(prev[j], big5int, q)
+					}
+
+					if step.State[j].Cmp(want) != 0 {
+						t.Fatalf("%d inputs: step %d: S-box of state[%d] is %s, expected %s", n, i, j, step.State[j], want)
+					}
+				}
+
+			case step.Op == "mix" && !partial:
+				matr := M
+				if step.Round == NROUNDSF/2-1 {
+					matr = P
+				}
+
+				for j := range step.State {
+					want := new(big.Int)
+					for k := range prev {
+						want.Add(want, new(big.Int).Mul(matr[k][j], prev[k]))
+					}
+
+					if step.State[j].Cmp(want.Mod(want, q)) != 0 {
+						t.Fatalf("%d inputs: step %d: mix of state[%d] is %s, expected %s", n, i, j, step.State[j], want)
+					}
+				}
+			}
+
+			prev = step.State
+		}
+
+		if len(keys) != len(C) {
+			t.Fatalf("%d inputs: %d round constants added, expected %d", n, len(keys), len(C))
+		}
+		for i := range C {
+			if keys[i].Cmp(C[i]) != 0 {
+				t.Fatalf("%d inputs: round constant %d is %s, expected %s", n, i, keys[i], C[i])
+			}
+		}
+	}
+
+	if _, err := HashTrace(nil); !errors.Is(err, ErrInputsLength) {
+		t.Fatalf("empty input returned %v", err)
+	}
+
+	if _, err := HashTrace([]*big.Int{q}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("element q returned %v", err)
+	}
+}
+
+func TestTraceExport(t *testing.T) {
+	tr, err := HashTrace(ints(1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var js bytes.Buffer
+	if err := tr.WriteJSON(&js); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded traceJSON
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Hash != Hash(ints(1, 2)).String() || decoded.Width != 3 || decoded.NRoundsP != NROUNDSP[1] || len(decoded.Steps) != len(tr.Steps) {
+		t.Fatalf("JSON trace header is %+v", decoded)
+	}
+
+	for i, step := range decoded.Steps {
+		if step.Op != tr.Steps[i].Op || step.Round != tr.Steps[i].Round || step.State[2] != tr.Steps[i].State[2].String() {
+			t.Fatalf("JSON step %d is %+v", i, step)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tr.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != len(tr.Steps)+1 || len(records[0]) != 4+3 || records[0][4] != "s0" {
+		t.Fatalf("CSV has %d records, header %v", len(records), records[0])
+	}
+
+	last := records[len(records)-1]
+	if last[2] != "full" || last[3] != "mix" || last[4] != tr.Hash.String() {
+		t.Fatalf("last CSV record is %v", last)
+	}
+
+	if partial := records[1+1+3*NROUNDSF/2]; partial[2] != "partial" || partial[3] != "sbox" {
+		t.Fatalf("first partial round record is %v", partial)
+	}
+}
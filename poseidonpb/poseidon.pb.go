@@ -0,0 +1,831 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: poseidon/v1/poseidon.proto
+
+package poseidonpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type HashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Elements      [][]byte               `protobuf:"bytes,1,rep,name=elements,proto3" json:"elements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashRequest) Reset() {
+	*x = HashRequest{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashRequest) ProtoMessage() {}
+
+func (x *HashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashRequest.ProtoReflect.Descriptor instead.
+func (*HashRequest) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HashRequest) GetElements() [][]byte {
+	if x != nil {
+		return x.Elements
+	}
+	return nil
+}
+
+type HashBytesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Data  []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// strict selects HashBytesStrict (length-prefixed, collision-resistant padding).
+	Strict        bool `protobuf:"varint,2,opt,name=strict,proto3" json:"strict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashBytesRequest) Reset() {
+	*x = HashBytesRequest{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashBytesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashBytesRequest) ProtoMessage() {}
+
+func (x *HashBytesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashBytesRequest.ProtoReflect.Descriptor instead.
+func (*HashBytesRequest) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HashBytesRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *HashBytesRequest) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+type HashStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Chunk []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	// strict and length are read from the first message only. Strict hashing absorbs the length
+	// first, so the total number of bytes must be declared upfront.
+	Strict        bool   `protobuf:"varint,2,opt,name=strict,proto3" json:"strict,omitempty"`
+	Length        uint64 `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashStreamRequest) Reset() {
+	*x = HashStreamRequest{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashStreamRequest) ProtoMessage() {}
+
+func (x *HashStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashStreamRequest.ProtoReflect.Descriptor instead.
+func (*HashStreamRequest) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HashStreamRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *HashStreamRequest) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+func (x *HashStreamRequest) GetLength() uint64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type HashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          []byte                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashResponse) Reset() {
+	*x = HashResponse{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashResponse) ProtoMessage() {}
+
+func (x *HashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashResponse.ProtoReflect.Descriptor instead.
+func (*HashResponse) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HashResponse) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+type BatchHashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*BatchItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchHashRequest) Reset() {
+	*x = BatchHashRequest{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchHashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchHashRequest) ProtoMessage() {}
+
+func (x *BatchHashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchHashRequest.ProtoReflect.Descriptor instead.
+func (*BatchHashRequest) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchHashRequest) GetItems() []*BatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type BatchItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Input:
+	//
+	//	*BatchItem_Elements
+	//	*BatchItem_Bytes
+	Input         isBatchItem_Input `protobuf_oneof:"input"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItem) Reset() {
+	*x = BatchItem{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItem) ProtoMessage() {}
+
+func (x *BatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItem.ProtoReflect.Descriptor instead.
+func (*BatchItem) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchItem) GetInput() isBatchItem_Input {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *BatchItem) GetElements() *HashRequest {
+	if x != nil {
+		if x, ok := x.Input.(*BatchItem_Elements); ok {
+			return x.Elements
+		}
+	}
+	return nil
+}
+
+func (x *BatchItem) GetBytes() *HashBytesRequest {
+	if x != nil {
+		if x, ok := x.Input.(*BatchItem_Bytes); ok {
+			return x.Bytes
+		}
+	}
+	return nil
+}
+
+type isBatchItem_Input interface {
+	isBatchItem_Input()
+}
+
+type BatchItem_Elements struct {
+	Elements *HashRequest `protobuf:"bytes,1,opt,name=elements,proto3,oneof"`
+}
+
+type BatchItem_Bytes struct {
+	Bytes *HashBytesRequest `protobuf:"bytes,2,opt,name=bytes,proto3,oneof"`
+}
+
+func (*BatchItem_Elements) isBatchItem_Input() {}
+
+func (*BatchItem_Bytes) isBatchItem_Input() {}
+
+type BatchHashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchResult         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchHashResponse) Reset() {
+	*x = BatchHashResponse{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchHashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchHashResponse) ProtoMessage() {}
+
+func (x *BatchHashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchHashResponse.ProtoReflect.Descriptor instead.
+func (*BatchHashResponse) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchHashResponse) GetResults() []*BatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BatchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*BatchResult_Hash
+	//	*BatchResult_Error
+	Result        isBatchResult_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchResult) Reset() {
+	*x = BatchResult{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResult) ProtoMessage() {}
+
+func (x *BatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResult.ProtoReflect.Descriptor instead.
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchResult) GetResult() isBatchResult_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *BatchResult) GetHash() []byte {
+	if x != nil {
+		if x, ok := x.Result.(*BatchResult_Hash); ok {
+			return x.Hash
+		}
+	}
+	return nil
+}
+
+func (x *BatchResult) GetError() string {
+	if x != nil {
+		if x, ok := x.Result.(*BatchResult_Error); ok {
+			return x.Error
+		}
+	}
+	return ""
+}
+
+type isBatchResult_Result interface {
+	isBatchResult_Result()
+}
+
+type BatchResult_Hash struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3,oneof"`
+}
+
+type BatchResult_Error struct {
+	Error string `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*BatchResult_Hash) isBatchResult_Result() {}
+
+func (*BatchResult_Error) isBatchResult_Result() {}
+
+type MerkleRootRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Leaves [][]byte               `protobuf:"bytes,1,rep,name=leaves,proto3" json:"leaves,omitempty"`
+	// arity is the number of children per node, 2..16.
+	Arity         uint32 `protobuf:"varint,2,opt,name=arity,proto3" json:"arity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MerkleRootRequest) Reset() {
+	*x = MerkleRootRequest{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MerkleRootRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MerkleRootRequest) ProtoMessage() {}
+
+func (x *MerkleRootRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MerkleRootRequest.ProtoReflect.Descriptor instead.
+func (*MerkleRootRequest) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MerkleRootRequest) GetLeaves() [][]byte {
+	if x != nil {
+		return x.Leaves
+	}
+	return nil
+}
+
+func (x *MerkleRootRequest) GetArity() uint32 {
+	if x != nil {
+		return x.Arity
+	}
+	return 0
+}
+
+type MerkleProofRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leaves        [][]byte               `protobuf:"bytes,1,rep,name=leaves,proto3" json:"leaves,omitempty"`
+	Arity         uint32                 `protobuf:"varint,2,opt,name=arity,proto3" json:"arity,omitempty"`
+	Index         uint32                 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MerkleProofRequest) Reset() {
+	*x = MerkleProofRequest{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MerkleProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MerkleProofRequest) ProtoMessage() {}
+
+func (x *MerkleProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MerkleProofRequest.ProtoReflect.Descriptor instead.
+func (*MerkleProofRequest) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MerkleProofRequest) GetLeaves() [][]byte {
+	if x != nil {
+		return x.Leaves
+	}
+	return nil
+}
+
+func (x *MerkleProofRequest) GetArity() uint32 {
+	if x != nil {
+		return x.Arity
+	}
+	return 0
+}
+
+func (x *MerkleProofRequest) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type MerkleProofStep struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// index is the position of the node among its arity siblings.
+	Index         uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Siblings      [][]byte `protobuf:"bytes,2,rep,name=siblings,proto3" json:"siblings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MerkleProofStep) Reset() {
+	*x = MerkleProofStep{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MerkleProofStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MerkleProofStep) ProtoMessage() {}
+
+func (x *MerkleProofStep) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MerkleProofStep.ProtoReflect.Descriptor instead.
+func (*MerkleProofStep) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MerkleProofStep) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *MerkleProofStep) GetSiblings() [][]byte {
+	if x != nil {
+		return x.Siblings
+	}
+	return nil
+}
+
+type MerkleProofResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Root  []byte                 `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	// steps go from the leaf level up to the root.
+	Steps         []*MerkleProofStep `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MerkleProofResponse) Reset() {
+	*x = MerkleProofResponse{}
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MerkleProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MerkleProofResponse) ProtoMessage() {}
+
+func (x *MerkleProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_poseidon_v1_poseidon_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MerkleProofResponse.ProtoReflect.Descriptor instead.
+func (*MerkleProofResponse) Descriptor() ([]byte, []int) {
+	return file_poseidon_v1_poseidon_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MerkleProofResponse) GetRoot() []byte {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *MerkleProofResponse) GetSteps() []*MerkleProofStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+var File_poseidon_v1_poseidon_proto protoreflect.FileDescriptor
+
+const file_poseidon_v1_poseidon_proto_rawDesc = "" +
+	"\n" +
+	"\x1aposeidon/v1/poseidon.proto\x12\vposeidon.v1\")\n" +
+	"\vHashRequest\x12\x1a\n" +
+	"\belements\x18\x01 \x03(\fR\belements\">\n" +
+	"\x10HashBytesRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x16\n" +
+	"\x06strict\x18\x02 \x01(\bR\x06strict\"Y\n" +
+	"\x11HashStreamRequest\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\x12\x16\n" +
+	"\x06strict\x18\x02 \x01(\bR\x06strict\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x04R\x06length\"\"\n" +
+	"\fHashResponse\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\fR\x04hash\"@\n" +
+	"\x10BatchHashRequest\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.poseidon.v1.BatchItemR\x05items\"\x83\x01\n" +
+	"\tBatchItem\x126\n" +
+	"\belements\x18\x01 \x01(\v2\x18.poseidon.v1.HashRequestH\x00R\belements\x125\n" +
+	"\x05bytes\x18\x02 \x01(\v2\x1d.poseidon.v1.HashBytesRequestH\x00R\x05bytesB\a\n" +
+	"\x05input\"G\n" +
+	"\x11BatchHashResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.poseidon.v1.BatchResultR\aresults\"E\n" +
+	"\vBatchResult\x12\x14\n" +
+	"\x04hash\x18\x01 \x01(\fH\x00R\x04hash\x12\x16\n" +
+	"\x05error\x18\x02 \x01(\tH\x00R\x05errorB\b\n" +
+	"\x06result\"A\n" +
+	"\x11MerkleRootRequest\x12\x16\n" +
+	"\x06leaves\x18\x01 \x03(\fR\x06leaves\x12\x14\n" +
+	"\x05arity\x18\x02 \x01(\rR\x05arity\"X\n" +
+	"\x12MerkleProofRequest\x12\x16\n" +
+	"\x06leaves\x18\x01 \x03(\fR\x06leaves\x12\x14\n" +
+	"\x05arity\x18\x02 \x01(\rR\x05arity\x12\x14\n" +
+	"\x05index\x18\x03 \x01(\rR\x05index\"C\n" +
+	"\x0fMerkleProofStep\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12\x1a\n" +
+	"\bsiblings\x18\x02 \x03(\fR\bsiblings\"]\n" +
+	"\x13MerkleProofResponse\x12\x12\n" +
+	"\x04root\x18\x01 \x01(\fR\x04root\x122\n" +
+	"\x05steps\x18\x02 \x03(\v2\x1c.poseidon.v1.MerkleProofStepR\x05steps2\xc7\x03\n" +
+	"\x0fPoseidonService\x12;\n" +
+	"\x04Hash\x12\x18.poseidon.v1.HashRequest\x1a\x19.poseidon.v1.HashResponse\x12E\n" +
+	"\tHashBytes\x12\x1d.poseidon.v1.HashBytesRequest\x1a\x19.poseidon.v1.HashResponse\x12I\n" +
+	"\n" +
+	"HashStream\x12\x1e.poseidon.v1.HashStreamRequest\x1a\x19.poseidon.v1.HashResponse(\x01\x12J\n" +
+	"\tBatchHash\x12\x1d.poseidon.v1.BatchHashRequest\x1a\x1e.poseidon.v1.BatchHashResponse\x12G\n" +
+	"\n" +
+	"MerkleRoot\x12\x1e.poseidon.v1.MerkleRootRequest\x1a\x19.poseidon.v1.HashResponse\x12P\n" +
+	"\vMerkleProof\x12\x1f.poseidon.v1.MerkleProofRequest\x1a .poseidon.v1.MerkleProofResponseB\x1eZ\x1cposeidonAlgorithm/poseidonpbb\x06proto3"
+
+var (
+	file_poseidon_v1_poseidon_proto_rawDescOnce sync.Once
+	file_poseidon_v1_poseidon_proto_rawDescData []byte
+)
+
+func file_poseidon_v1_poseidon_proto_rawDescGZIP() []byte {
+	file_poseidon_v1_poseidon_proto_rawDescOnce.Do(func() {
+		file_poseidon_v1_poseidon_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_poseidon_v1_poseidon_proto_rawDesc), len(file_poseidon_v1_poseidon_proto_rawDesc)))
+	})
+	return file_poseidon_v1_poseidon_proto_rawDescData
+}
+
+var file_poseidon_v1_poseidon_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_poseidon_v1_poseidon_proto_goTypes = []any{
+	(*HashRequest)(nil),         // 0: poseidon.v1.HashRequest
+	(*HashBytesRequest)(nil),    // 1: poseidon.v1.HashBytesRequest
+	(*HashStreamRequest)(nil),   // 2: poseidon.v1.HashStreamRequest
+	(*HashResponse)(nil),        // 3: poseidon.v1.HashResponse
+	(*BatchHashRequest)(nil),    // 4: poseidon.v1.BatchHashRequest
+	(*BatchItem)(nil),           // 5: poseidon.v1.BatchItem
+	(*BatchHashResponse)(nil),   // 6: poseidon.v1.BatchHashResponse
+	(*BatchResult)(nil),         // 7: poseidon.v1.BatchResult
+	(*MerkleRootRequest)(nil),   // 8: poseidon.v1.MerkleRootRequest
+	(*MerkleProofRequest)(nil),  // 9: poseidon.v1.MerkleProofRequest
+	(*MerkleProofStep)(nil),     // 10: poseidon.v1.MerkleProofStep
+	(*MerkleProofResponse)(nil), // 11: poseidon.v1.MerkleProofResponse
+}
+var file_poseidon_v1_poseidon_proto_depIdxs = []int32{
+	5,  // 0: poseidon.v1.BatchHashRequest.items:type_name -> poseidon.v1.BatchItem
+	0,  // 1: poseidon.v1.BatchItem.elements:type_name -> poseidon.v1.HashRequest
+	1,  // 2: poseidon.v1.BatchItem.bytes:type_name -> poseidon.v1.HashBytesRequest
+	7,  // 3: poseidon.v1.BatchHashResponse.results:type_name -> poseidon.v1.BatchResult
+	10, // 4: poseidon.v1.MerkleProofResponse.steps:type_name -> poseidon.v1.MerkleProofStep
+	0,  // 5: poseidon.v1.PoseidonService.Hash:input_type -> poseidon.v1.HashRequest
+	1,  // 6: poseidon.v1.PoseidonService.HashBytes:input_type -> poseidon.v1.HashBytesRequest
+	2,  // 7: poseidon.v1.PoseidonService.HashStream:input_type -> poseidon.v1.HashStreamRequest
+	4,  // 8: poseidon.v1.PoseidonService.BatchHash:input_type -> poseidon.v1.BatchHashRequest
+	8,  // 9: poseidon.v1.PoseidonService.MerkleRoot:input_type -> poseidon.v1.MerkleRootRequest
+	9,  // 10: poseidon.v1.PoseidonService.MerkleProof:input_type -> poseidon.v1.MerkleProofRequest
+	3,  // 11: poseidon.v1.PoseidonService.Hash:output_type -> poseidon.v1.HashResponse
+	3,  // 12: poseidon.v1.PoseidonService.HashBytes:output_type -> poseidon.v1.HashResponse
+	3,  // 13: poseidon.v1.PoseidonService.HashStream:output_type -> poseidon.v1.HashResponse
+	6,  // 14: poseidon.v1.PoseidonService.BatchHash:output_type -> poseidon.v1.BatchHashResponse
+	3,  // 15: poseidon.v1.PoseidonService.MerkleRoot:output_type -> poseidon.v1.HashResponse
+	11, // 16: poseidon.v1.PoseidonService.MerkleProof:output_type -> poseidon.v1.MerkleProofResponse
+	11, // [11:17] is the sub-list for method output_type
+	5,  // [5:11] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_poseidon_v1_poseidon_proto_init() }
+func file_poseidon_v1_poseidon_proto_init() {
+	if File_poseidon_v1_poseidon_proto != nil {
+		return
+	}
+	file_poseidon_v1_poseidon_proto_msgTypes[5].OneofWrappers = []any{
+		(*BatchItem_Elements)(nil),
+		(*BatchItem_Bytes)(nil),
+	}
+	file_poseidon_v1_poseidon_proto_msgTypes[7].OneofWrappers = []any{
+		(*BatchResult_Hash)(nil),
+		(*BatchResult_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_poseidon_v1_poseidon_proto_rawDesc), len(file_poseidon_v1_poseidon_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_poseidon_v1_poseidon_proto_goTypes,
+		DependencyIndexes: file_poseidon_v1_poseidon_proto_depIdxs,
+		MessageInfos:      file_poseidon_v1_poseidon_proto_msgTypes,
+	}.Build()
+	File_poseidon_v1_poseidon_proto = out.File
+	file_poseidon_v1_poseidon_proto_goTypes = nil
+	file_poseidon_v1_poseidon_proto_depIdxs = nil
+}
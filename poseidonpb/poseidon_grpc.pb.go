@@ -0,0 +1,329 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: poseidon/v1/poseidon.proto
+
+package poseidonpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PoseidonService_Hash_FullMethodName        = "/poseidon.v1.PoseidonService/Hash"
+	PoseidonService_HashBytes_FullMethodName   = "/poseidon.v1.PoseidonService/HashBytes"
+	PoseidonService_HashStream_FullMethodName  = "/poseidon.v1.PoseidonService/HashStream"
+	PoseidonService_BatchHash_FullMethodName   = "/poseidon.v1.PoseidonService/BatchHash"
+	PoseidonService_MerkleRoot_FullMethodName  = "/poseidon.v1.PoseidonService/MerkleRoot"
+	PoseidonService_MerkleProof_FullMethodName = "/poseidon.v1.PoseidonService/MerkleProof"
+)
+
+// PoseidonServiceClient is the client API for PoseidonService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PoseidonService exposes the Poseidon hash over the BN254 scalar field (circomlib constants).
+//
+// Field elements and digests are big-endian unsigned integers of at most 32 bytes that must be
+// less than the field modulus. Digests are always returned as exactly 32 bytes.
+type PoseidonServiceClient interface {
+	// Hash hashes 1..16 field elements with Hash.
+	Hash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*HashResponse, error)
+	// HashBytes hashes a byte string with HashBytes (or HashBytesStrict when strict is set).
+	HashBytes(ctx context.Context, in *HashBytesRequest, opts ...grpc.CallOption) (*HashResponse, error)
+	// HashStream hashes a byte string sent in chunks. The digest equals HashBytes (or HashBytesStrict)
+	// of the concatenated chunks; the server never buffers the whole input.
+	HashStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HashStreamRequest, HashResponse], error)
+	// BatchHash hashes independent requests; an invalid item yields an error result, not an RPC error.
+	BatchHash(ctx context.Context, in *BatchHashRequest, opts ...grpc.CallOption) (*BatchHashResponse, error)
+	// MerkleRoot computes the root of a Merkle tree with domain-separated leaf and node hashing (see MerkleRoot in Go).
+	MerkleRoot(ctx context.Context, in *MerkleRootRequest, opts ...grpc.CallOption) (*HashResponse, error)
+	// MerkleProof returns the inclusion proof of one leaf together with the tree root.
+	MerkleProof(ctx context.Context, in *MerkleProofRequest, opts ...grpc.CallOption) (*MerkleProofResponse, error)
+}
+
+type poseidonServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPoseidonServiceClient(cc grpc.ClientConnInterface) PoseidonServiceClient {
+	return &poseidonServiceClient{cc}
+}
+
+func (c *poseidonServiceClient) Hash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*HashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HashResponse)
+	err := c.cc.Invoke(ctx, PoseidonService_Hash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poseidonServiceClient) HashBytes(ctx context.Context, in *HashBytesRequest, opts ...grpc.CallOption) (*HashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HashResponse)
+	err := c.cc.Invoke(ctx, PoseidonService_HashBytes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poseidonServiceClient) HashStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[HashStreamRequest, HashResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PoseidonService_ServiceDesc.Streams[0], PoseidonService_HashStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HashStreamRequest, HashResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PoseidonService_HashStreamClient = grpc.ClientStreamingClient[HashStreamRequest, HashResponse]
+
+func (c *poseidonServiceClient) BatchHash(ctx context.Context, in *BatchHashRequest, opts ...grpc.CallOption) (*BatchHashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchHashResponse)
+	err := c.cc.Invoke(ctx, PoseidonService_BatchHash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poseidonServiceClient) MerkleRoot(ctx context.Context, in *MerkleRootRequest, opts ...grpc.CallOption) (*HashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HashResponse)
+	err := c.cc.Invoke(ctx, PoseidonService_MerkleRoot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *poseidonServiceClient) MerkleProof(ctx context.Context, in *MerkleProofRequest, opts ...grpc.CallOption) (*MerkleProofResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MerkleProofResponse)
+	err := c.cc.Invoke(ctx, PoseidonService_MerkleProof_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PoseidonServiceServer is the server API for PoseidonService service.
+// All implementations must embed UnimplementedPoseidonServiceServer
+// for forward compatibility.
+//
+// PoseidonService exposes the Poseidon hash over the BN254 scalar field (circomlib constants).
+//
+// Field elements and digests are big-endian unsigned integers of at most 32 bytes that must be
+// less than the field modulus. Digests are always returned as exactly 32 bytes.
+type PoseidonServiceServer interface {
+	// Hash hashes 1..16 field elements with Hash.
+	Hash(context.Context, *HashRequest) (*HashResponse, error)
+	// HashBytes hashes a byte string with HashBytes (or HashBytesStrict when strict is set).
+	HashBytes(context.Context, *HashBytesRequest) (*HashResponse, error)
+	// HashStream hashes a byte string sent in chunks. The digest equals HashBytes (or HashBytesStrict)
+	// of the concatenated chunks; the server never buffers the whole input.
+	HashStream(grpc.ClientStreamingServer[HashStreamRequest, HashResponse]) error
+	// BatchHash hashes independent requests; an invalid item yields an error result, not an RPC error.
+	BatchHash(context.Context, *BatchHashRequest) (*BatchHashResponse, error)
+	// MerkleRoot computes the root of a Merkle tree with domain-separated leaf and node hashing (see MerkleRoot in Go).
+	MerkleRoot(context.Context, *MerkleRootRequest) (*HashResponse, error)
+	// MerkleProof returns the inclusion proof of one leaf together with the tree root.
+	MerkleProof(context.Context, *MerkleProofRequest) (*MerkleProofResponse, error)
+	mustEmbedUnimplementedPoseidonServiceServer()
+}
+
+// UnimplementedPoseidonServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPoseidonServiceServer struct{}
+
+func (UnimplementedPoseidonServiceServer) Hash(context.Context, *HashRequest) (*HashResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Hash not implemented")
+}
+func (UnimplementedPoseidonServiceServer) HashBytes(context.Context, *HashBytesRequest) (*HashResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method HashBytes not implemented")
+}
+func (UnimplementedPoseidonServiceServer) HashStream(grpc.ClientStreamingServer[HashStreamRequest, HashResponse]) error {
+	return status.Error(codes.Unimplemented, "method HashStream not implemented")
+}
+func (UnimplementedPoseidonServiceServer) BatchHash(context.Context, *BatchHashRequest) (*BatchHashResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchHash not implemented")
+}
+func (UnimplementedPoseidonServiceServer) MerkleRoot(context.Context, *MerkleRootRequest) (*HashResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MerkleRoot not implemented")
+}
+func (UnimplementedPoseidonServiceServer) MerkleProof(context.Context, *MerkleProofRequest) (*MerkleProofResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MerkleProof not implemented")
+}
+func (UnimplementedPoseidonServiceServer) mustEmbedUnimplementedPoseidonServiceServer() {}
+func (UnimplementedPoseidonServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafePoseidonServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PoseidonServiceServer will
+// result in compilation errors.
+type UnsafePoseidonServiceServer interface {
+	mustEmbedUnimplementedPoseidonServiceServer()
+}
+
+func RegisterPoseidonServiceServer(s grpc.ServiceRegistrar, srv PoseidonServiceServer) {
+	// If the following call panics, it indicates UnimplementedPoseidonServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PoseidonService_ServiceDesc, srv)
+}
+
+func _PoseidonService_Hash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoseidonServiceServer).Hash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoseidonService_Hash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoseidonServiceServer).Hash(ctx, req.(*HashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoseidonService_HashBytes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashBytesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoseidonServiceServer).HashBytes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoseidonService_HashBytes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoseidonServiceServer).HashBytes(ctx, req.(*HashBytesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoseidonService_HashStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PoseidonServiceServer).HashStream(&grpc.GenericServerStream[HashStreamRequest, HashResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PoseidonService_HashStreamServer = grpc.ClientStreamingServer[HashStreamRequest, HashResponse]
+
+func _PoseidonService_BatchHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoseidonServiceServer).BatchHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoseidonService_BatchHash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoseidonServiceServer).BatchHash(ctx, req.(*BatchHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoseidonService_MerkleRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerkleRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoseidonServiceServer).MerkleRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoseidonService_MerkleRoot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoseidonServiceServer).MerkleRoot(ctx, req.(*MerkleRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PoseidonService_MerkleProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MerkleProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PoseidonServiceServer).MerkleProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PoseidonService_MerkleProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PoseidonServiceServer).MerkleProof(ctx, req.(*MerkleProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PoseidonService_ServiceDesc is the grpc.ServiceDesc for PoseidonService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PoseidonService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "poseidon.v1.PoseidonService",
+	HandlerType: (*PoseidonServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Hash",
+			Handler:    _PoseidonService_Hash_Handler,
+		},
+		{
+			MethodName: "HashBytes",
+			Handler:    _PoseidonService_HashBytes_Handler,
+		},
+		{
+			MethodName: "BatchHash",
+			Handler:    _PoseidonService_BatchHash_Handler,
+		},
+		{
+			MethodName: "MerkleRoot",
+			Handler:    _PoseidonService_MerkleRoot_Handler,
+		},
+		{
+			MethodName: "MerkleProof",
+			Handler:    _PoseidonService_MerkleProof_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "HashStream",
+			Handler:       _PoseidonService_HashStream_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "poseidon/v1/poseidon.proto",
+}
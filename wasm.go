@@ -0,0 +1,69 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"syscall/js"
+
+	"poseidonAlgorithm/poseidon"
+)
+
+// main - точка входу WebAssembly-збірки (GOOS=js GOARCH=wasm або TinyGo з -target wasm): реєструє в глобальному
+// об'єкті JavaScript функції poseidonHash і poseidonHashBytes і блокується, щоб функції залишалися доступними
+func main() {
+	js.Global().Set("poseidonHash", js.FuncOf(jsHash))
+	js.Global().Set("poseidonHashBytes", js.FuncOf(jsHashBytes))
+
+	select {}
+}
+
+// jsHash - poseidonHash(elems: string[]): string - геш Hash від 1..16 елементів поля, заданих десятковими рядками або
+// рядками з префіксом 0x; повертає геш у десятковому вигляді або об'єкт Error для некоректного входу
+func jsHash(_ js.Value, args []js.Value) interface{} {
+	if len(args) != 1 || !js.Global().Get("Array").Call("isArray", args[0]).Bool() {
+		return jsError(errors.New("poseidonHash expects an array of strings"))
+	}
+
+	n := args[0].Length()
+	if n == 0 || n > poseidon.INPUTS {
+		return jsError(fmt.Errorf("expected 1..%d elements, got %d", poseidon.INPUTS, n))
+	}
+
+	input := make([]*big.Int, n)
+
+	for i := range input {
+		v := args[0].Index(i)
+		if v.Type() != js.TypeString {
+			return jsError(fmt.Errorf("element %d is not a string", i))
+		}
+
+		x, err := poseidon.ElementFromString(v.String())
+		if err != nil {
+			return jsError(fmt.Errorf("element %d: %w", i, err))
+		}
+		input[i] = x.BigInt()
+	}
+
+	return poseidon.Hash(input).String()
+}
+
+// jsHashBytes - poseidonHashBytes(data: Uint8Array): string - геш HashBytes у десятковому вигляді
+// (або об'єкт Error, якщо аргумент не Uint8Array)
+func jsHashBytes(_ js.Value, args []js.Value) interface{} {
+	if len(args) != 1 || !args[0].InstanceOf(js.Global().Get("Uint8Array")) {
+		return jsError(errors.New("poseidonHashBytes expects a Uint8Array"))
+	}
+
+	data := make([]byte, args[0].Length())
+	js.CopyBytesToGo(data, args[0])
+
+	return poseidon.HashBytes(data).String()
+}
+
+// jsError - функція створення об'єкта Error JavaScript (виняток з Go кинути неможливо, тому помилка повертається)
+func jsError(err error) js.Value {
+	return js.Global().Get("Error").New(err.Error())
+}
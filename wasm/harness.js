@@ -0,0 +1,72 @@
+'use strict';
+
+// Node test harness: compares the WebAssembly build against digests computed by the Go implementation.
+//
+//   node wasm/harness.js <wasm_exec.js> <poseidon.wasm> <vectors.json>
+//
+// vectors.json is written by TestWASMHarness (wasm_test.go):
+//   { "hash": [{ "elements": ["1", "0x2"], "hash": "..." }],
+//     "hashBytes": [{ "data": "<hex>", "hash": "..." }],
+//     "invalid": [["<element>", ...]] }
+
+const fs = require('fs');
+const path = require('path');
+
+async function main() {
+  const [wasmExec, wasmFile, vectorsFile] = process.argv.slice(2);
+  if (!vectorsFile) {
+    console.error('usage: node harness.js <wasm_exec.js> <poseidon.wasm> <vectors.json>');
+    process.exit(2);
+  }
+
+  require(path.resolve(wasmExec));
+  const { loadPoseidon } = require('./poseidon.js');
+
+  const { poseidonHash, poseidonHashBytes } = await loadPoseidon(fs.readFileSync(wasmFile));
+  const vectors = JSON.parse(fs.readFileSync(vectorsFile, 'utf8'));
+
+  let failed = 0;
+  const fail = (msg) => {
+    console.error(`FAIL ${msg}`);
+    failed++;
+  };
+
+  for (const v of vectors.hash) {
+    const got = poseidonHash(v.elements);
+    if (got !== v.hash) {
+      fail(`poseidonHash(${JSON.stringify(v.elements)}) = ${got}, expected ${v.hash}`);
+    }
+  }
+
+  for (const v of vectors.hashBytes) {
+    const got = poseidonHashBytes(Uint8Array.from(Buffer.from(v.data, 'hex')));
+    if (got !== v.hash) {
+      fail(`poseidonHashBytes(${v.data.length / 2} bytes) = ${got}, expected ${v.hash}`);
+    }
+  }
+
+  for (const elements of vectors.invalid) {
+    try {
+      poseidonHash(elements);
+      fail(`poseidonHash(${JSON.stringify(elements)}) did not throw`);
+    } catch (e) {
+      // expected
+    }
+  }
+
+  try {
+    poseidonHashBytes('not bytes');
+    fail('poseidonHashBytes(string) did not throw');
+  } catch (e) {
+    // expected
+  }
+
+  const total = vectors.hash.length + vectors.hashBytes.length + vectors.invalid.length + 1;
+  console.log(`${total - failed}/${total} checks passed`);
+  process.exit(failed === 0 ? 0 : 1);
+}
+
+main().catch((e) => {
+  console.error(e);
+  process.exit(1);
+});
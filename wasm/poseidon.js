@@ -0,0 +1,36 @@
+'use strict';
+
+// Loader for the WebAssembly build of the Poseidon hash.
+//
+//   GOOS=js GOARCH=wasm go build -o poseidon.wasm .        (use $(go env GOROOT)/lib/wasm/wasm_exec.js)
+//   tinygo build -o poseidon.wasm -target wasm .            (use $(tinygo env TINYGOROOT)/targets/wasm_exec.js)
+//
+// wasm_exec.js of the same toolchain must be loaded first: it defines globalThis.Go.
+
+// loadPoseidon instantiates the module and returns { poseidonHash, poseidonHashBytes }.
+// Both functions return the digest as a decimal string and throw an Error on invalid input.
+async function loadPoseidon(wasm, Go = globalThis.Go) {
+  if (typeof Go !== 'function') {
+    throw new Error('wasm_exec.js is not loaded');
+  }
+
+  const go = new Go();
+  const { instance } = await WebAssembly.instantiate(wasm, go.importObject);
+
+  go.run(instance); // main registers the functions and blocks, so the promise is not awaited
+
+  const wrap = (fn) => (...args) => {
+    const result = fn(...args);
+    if (result instanceof Error) {
+      throw result;
+    }
+    return result;
+  };
+
+  return {
+    poseidonHash: wrap(globalThis.poseidonHash),
+    poseidonHashBytes: wrap(globalThis.poseidonHashBytes),
+  };
+}
+
+module.exports = { loadPoseidon };
@@ -0,0 +1,115 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"poseidonAlgorithm/poseidon"
+)
+
+// q - порядок скалярного поля BN254
+var q, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+func testBytes(n int) []byte {
+	msg := make([]byte, n)
+	for i := range msg {
+		msg[i] = byte(i*31 + 7)
+	}
+	return msg
+}
+
+type wasmHashVector struct {
+	Elements []string `json:"elements"`
+	Hash     string   `json:"hash"`
+}
+
+type wasmBytesVector struct {
+	Data string `json:"data"` // повідомлення в шістнадцятковому вигляді
+	Hash string `json:"hash"`
+}
+
+// wasmVectors - вектори для wasm/harness.js, обчислені реалізацією на Go
+type wasmVectors struct {
+	Hash      []wasmHashVector  `json:"hash"`
+	HashBytes []wasmBytesVector `json:"hashBytes"`
+	Invalid   [][]string        `json:"invalid"`
+}
+
+func newWASMVectors() wasmVectors {
+	var v wasmVectors
+
+	for n := 1; n <= poseidon.INPUTS; n++ {
+		elements := make([]string, n)
+		input := make([]*big.Int, n)
+
+		for i := range input {
+			input[i] = new(big.Int).Sub(q, big.NewInt(int64(i*n+1))) // великі елементи поля, частина в шістнадцятковому вигляді
+			elements[i] = input[i].String()
+			if i%2 == 1 {
+				elements[i] = fmt.Sprintf("0x%064x", input[i])
+			}
+		}
+
+		v.Hash = append(v.Hash, wasmHashVector{Elements: elements, Hash: poseidon.Hash(input).String()})
+	}
+
+	for _, n := range []int{0, 1, 30, 31, 32, 496, 497, 600} {
+		v.HashBytes = append(v.HashBytes, wasmBytesVector{Data: hex.EncodeToString(testBytes(n)), Hash: poseidon.HashBytes(testBytes(n)).String()})
+	}
+
+	v.Invalid = [][]string{{}, {q.String()}, {"-1"}, {"abc"}, make([]string, poseidon.INPUTS+1)}
+
+	return v
+}
+
+// TestWASMHarness - збирає WebAssembly-версію і порівнює її результати в Node.js з реалізацією на Go
+func TestWASMHarness(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping WebAssembly build in short mode")
+	}
+
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is not installed")
+	}
+
+	goTool := filepath.Join(runtime.GOROOT(), "bin", "go")
+	wasmExec := filepath.Join(runtime.GOROOT(), "lib", "wasm", "wasm_exec.js")
+	if _, err := os.Stat(wasmExec); err != nil {
+		wasmExec = filepath.Join(runtime.GOROOT(), "misc", "wasm", "wasm_exec.js") // Go до 1.24
+	}
+
+	dir := t.TempDir()
+	wasmFile := filepath.Join(dir, "poseidon.wasm")
+
+	build := exec.Command(goTool, "build", "-o", wasmFile, ".")
+	build.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("wasm build failed: %v\n%s", err, out)
+	}
+
+	vectors, err := json.Marshal(newWASMVectors())
+	if err != nil {
+		t.Fatal(err)
+	}
+	vectorsFile := filepath.Join(dir, "vectors.json")
+	if err := os.WriteFile(vectorsFile, vectors, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command(node, filepath.Join("wasm", "harness.js"), wasmExec, wasmFile, vectorsFile).CombinedOutput()
+	if err != nil {
+		t.Fatalf("harness failed: %v\n%s", err, out)
+	}
+
+	t.Logf("%s", out)
+}